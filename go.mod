@@ -5,12 +5,14 @@ go 1.22.4
 toolchain go1.22.5
 
 require (
-	github.com/blacknon/go-sshproc v0.1.1
+	github.com/BurntSushi/toml v1.4.0
+	github.com/blacknon/go-sshlib v0.1.18
 	github.com/blacknon/lssh v0.6.13
 	github.com/blacknon/mview v0.1.5
 	github.com/c9s/goprocinfo v0.0.0-20210130143923-c95fcf8c64a8
 	github.com/dustin/go-humanize v1.0.0
 	github.com/gdamore/tcell/v2 v2.7.1
+	github.com/pkg/sftp v1.13.6
 	github.com/urfave/cli v1.21.0
 	golang.org/x/crypto v0.26.0
 )
@@ -18,13 +20,11 @@ require (
 require (
 	code.rocketnine.space/tslocum/cbind v0.1.5 // indirect
 	github.com/Azure/go-ansiterm v0.0.0-20230124172434-306776ec8161 // indirect
-	github.com/BurntSushi/toml v1.4.0 // indirect
 	github.com/ScaleFT/sshkeys v1.2.0 // indirect
 	github.com/VividCortex/ewma v1.2.0 // indirect
 	github.com/armon/go-socks5 v0.0.0-20160902184237-e75332964ef5 // indirect
 	github.com/blacknon/crypto11 v1.2.7 // indirect
 	github.com/blacknon/go-nfs-sshlib v0.0.3 // indirect
-	github.com/blacknon/go-sshlib v0.1.18 // indirect
 	github.com/blacknon/go-x11auth v0.1.0 // indirect
 	github.com/cyphar/filepath-securejoin v0.2.4 // indirect
 	github.com/dchest/bcrypt_pbkdf v0.0.0-20150205184540-83f37f9c154a // indirect
@@ -43,7 +43,6 @@ require (
 	github.com/moby/term v0.5.0 // indirect
 	github.com/nsf/termbox-go v1.1.1 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
-	github.com/pkg/sftp v1.13.6 // indirect
 	github.com/rasky/go-xdr v0.0.0-20170124162913-1a41d1a06c93 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
 	github.com/sevlyar/go-daemon v0.1.5 // indirect
@@ -13,6 +13,7 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	"net/http"
 	_ "net/http/pprof"
@@ -78,9 +79,15 @@ USAGE:
 		cli.StringSliceFlag{Name: "host,H", Usage: "connect `servername`."},
 		cli.StringFlag{Name: "file,F", Value: defConf, Usage: "config `filepath`."},
 		cli.StringFlag{Name: "logfile,L", Usage: "Set log file path."},
+		cli.StringFlag{Name: "backend,b", Value: mon.BackendSFTP, Usage: "collection `backend`. sftp or exec(read all /proc files with one command per tick)."},
+		cli.DurationFlag{Name: "interval,i", Value: mon.DefaultInterval, Usage: "sampling `interval`. can be overridden per host by `lsmon_interval` in config."},
+		cli.IntFlag{Name: "history", Value: mon.DefaultHistory, Usage: "number of `samples` kept per metric. can be overridden per host by `lsmon_history` in config."},
 
 		// Other bool
 		cli.BoolFlag{Name: "list,l", Usage: "print server list from config."},
+		cli.BoolFlag{Name: "local", Usage: "monitor the local machine without SSH."},
+		cli.BoolFlag{Name: "bits", Usage: "show network rates in bits per second."},
+		cli.BoolFlag{Name: "psi", Usage: "show pressure stall information(some/full avg10/avg60) columns in server list."},
 		cli.BoolFlag{Name: "debug", Usage: "debug pprof. use port 6060."},
 		cli.BoolFlag{Name: "help,h", Usage: "print this help"},
 	}
@@ -114,14 +121,58 @@ USAGE:
 
 		debug := c.Bool("debug")
 
+		option := &mon.Option{
+			Backend:  c.String("backend"),
+			Interval: c.Duration("interval"),
+			History:  c.Int("history"),
+
+			NetworkBits: c.Bool("bits"),
+			Pressure:    c.Bool("psi"),
+		}
+
+		if option.Backend != mon.BackendSFTP && option.Backend != mon.BackendExec {
+			fmt.Fprintf(os.Stderr, "Unknown backend: %s\n", option.Backend)
+			os.Exit(1)
+		}
+
+		if option.Interval < time.Second {
+			fmt.Fprintln(os.Stderr, "interval must be 1s or more.")
+			os.Exit(1)
+		}
+
+		if option.History < 2 {
+			fmt.Fprintln(os.Stderr, "history must be 2 or more.")
+			os.Exit(1)
+		}
+
 		// Get config data
 		data := conf.Read(confpath)
 
+		// Get lsmon settings from config
+		config, err := mon.ReadConfig(confpath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		option.Config = config
+
 		// Set `exec command` or `shell` flag
 		isMulti := true
 
 		// Extraction server name list from 'data'
 		names := conf.GetNameList(data)
+
+		// Add local machine to server list.
+		// If `localhost` is defined in config, it is connected by ssh unless `--local` is specified.
+		if _, ok := data.Server[mon.LocalServerName]; !ok {
+			data.Server[mon.LocalServerName] = conf.ServerConfig{
+				Addr: "localhost",
+				User: getUserName(),
+				Note: "local machine (read /proc without ssh)",
+			}
+			names = append(names, mon.LocalServerName)
+			option.LocalServer = mon.LocalServerName
+		}
 		sort.Strings(names)
 
 		// Check list flag
@@ -134,7 +185,15 @@ USAGE:
 		}
 
 		selected := []string{}
-		if len(hosts) > 0 {
+		if c.Bool("local") {
+			option.LocalServer = mon.LocalServerName
+
+			if len(hosts) > 0 && !check.ExistServer(hosts, names) {
+				fmt.Fprintln(os.Stderr, "Input Server not found from list.")
+				os.Exit(1)
+			}
+			selected = common.GetUniqueSlice(append(hosts, mon.LocalServerName))
+		} else if len(hosts) > 0 {
 			if !check.ExistServer(hosts, names) {
 				fmt.Fprintln(os.Stderr, "Input Server not found from list.")
 				os.Exit(1)
@@ -162,8 +221,6 @@ USAGE:
 		r.Conf = data
 		r.Conf.Common.ConnectTimeout = 5
 
-		var err error
-
 		// if err
 		if err != nil {
 			fmt.Printf("Error: %s \n", err)
@@ -189,12 +246,22 @@ USAGE:
 		// create AuthMap
 		r.CreateAuthMethodMap()
 
-		err = mon.Run(r)
+		err = mon.Run(r, option)
 		return err
 	}
 	return app
 }
 
+// getUserName return current user name.
+func getUserName() string {
+	usr, err := user.Current()
+	if err != nil {
+		return ""
+	}
+
+	return usr.Username
+}
+
 // getAbsPath return absolute path convert.
 // Replace `~` with your home directory.
 func getAbsPath(path string) string {
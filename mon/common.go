@@ -11,6 +11,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/c9s/goprocinfo/linux"
 )
 
 func sumFloat64(values ...float64) (sum float64) {
@@ -21,6 +23,21 @@ func sumFloat64(values ...float64) (sum float64) {
 	return sum
 }
 
+func cpuStatTotal(s linux.CPUStat) float64 {
+	return sumFloat64(
+		float64(s.User),
+		float64(s.Nice),
+		float64(s.System),
+		float64(s.Idle),
+		float64(s.IOWait),
+		float64(s.IRQ),
+		float64(s.SoftIRQ),
+		float64(s.Steal),
+		float64(s.Guest),
+		float64(s.GuestNice),
+	)
+}
+
 func uptimeFormatDuration(d time.Duration) string {
 	// 総時間数を秒単位で取得
 	totalSeconds := int64(d.Seconds())
@@ -120,6 +137,8 @@ func parseSize(size string) int64 {
 	case strings.HasSuffix(size, "TB"):
 		factor = 1024 * 1024 * 1024 * 1024
 		size = strings.TrimSuffix(size, "TB")
+	case strings.HasSuffix(size, "B"):
+		size = strings.TrimSuffix(size, "B")
 	}
 
 	value, err := strconv.ParseFloat(strings.TrimSpace(size), 64)
@@ -129,3 +148,33 @@ func parseSize(size string) int64 {
 
 	return int64(value * float64(factor))
 }
+
+// getLVMMapperPath is convert LVM path(/dev/<vg>/<lv>) to device-mapper path(/dev/mapper/<vg>-<lv>).
+// "-" in vg and lv names is escaped as "--".
+func getLVMMapperPath(device string) string {
+	elements := strings.Split(strings.TrimPrefix(device, "/dev/"), "/")
+	if !strings.HasPrefix(device, "/dev/") || len(elements) != 2 {
+		return ""
+	}
+
+	vg := strings.ReplaceAll(elements[0], "-", "--")
+	lv := strings.ReplaceAll(elements[1], "-", "--")
+
+	return "/dev/mapper/" + vg + "-" + lv
+}
+
+// counterDelta is return the increase of a monotonic counter from pre to cur.
+// If the counter is decreased and pre is near the limit of 32bit, it is treated as wraparound of 32bit counter
+// (/proc/net/dev and /proc/diskstats are 32bit on 32bit kernel).
+// Otherwise the counter was reset (ex: interface flap, module reload), and ok is false.
+func counterDelta(cur, pre uint64) (delta uint64, ok bool) {
+	if cur >= pre {
+		return cur - pre, true
+	}
+
+	if pre <= math.MaxUint32 && pre > math.MaxUint32/2 && cur <= math.MaxUint32/2 {
+		return math.MaxUint32 - pre + cur + 1, true
+	}
+
+	return 0, false
+}
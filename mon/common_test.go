@@ -0,0 +1,37 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCounterDelta(t *testing.T) {
+	tests := []struct {
+		name  string
+		cur   uint64
+		pre   uint64
+		delta uint64
+		ok    bool
+	}{
+		{"normal", 1500, 1000, 500, true},
+		{"unchanged", 1000, 1000, 0, true},
+		{"normal 64bit", math.MaxUint32 + 100, math.MaxUint32 - 100, 200, true},
+		{"wrap 32bit", 99, math.MaxUint32 - 100, 200, true},
+		{"wrap 32bit at limit", 0, math.MaxUint32, 1, true},
+		{"reset to 0", 0, 1000, 0, false},
+		{"reset 64bit", 1000, math.MaxUint32 + 1000, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta, ok := counterDelta(tt.cur, tt.pre)
+			if delta != tt.delta || ok != tt.ok {
+				t.Errorf("counterDelta(%d, %d) = (%d, %v), want (%d, %v)", tt.cur, tt.pre, delta, ok, tt.delta, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	// DefaultInterval is the default sampling interval.
+	DefaultInterval = 2 * time.Second
+
+	// DefaultHistory is the default number of samples kept per metric.
+	DefaultHistory = 480
+)
+
+// Config is lsmon settings read from the lssh config file.
+// lssh ignores unknown keys, so the settings can be written in the server section.
+//
+//	[server.wan-host]
+//	addr = "192.168.100.10"
+//	user = "user"
+//	lsmon_interval = "10s"
+//	lsmon_history = 240
+type Config struct {
+	Server map[string]ServerConfig `toml:"server"`
+}
+
+// ServerConfig is per host lsmon settings.
+// Empty value means that the value of command line flag is used.
+type ServerConfig struct {
+	Interval string `toml:"lsmon_interval"`
+	History  int    `toml:"lsmon_history"`
+}
+
+// ReadConfig is read lsmon settings from the lssh config file.
+func ReadConfig(path string) (c Config, err error) {
+	c.Server = map[string]ServerConfig{}
+
+	if _, serr := os.Stat(path); serr != nil {
+		return
+	}
+
+	_, err = toml.DecodeFile(path, &c)
+	if err != nil {
+		return
+	}
+
+	// check values
+	for server, sc := range c.Server {
+		if sc.Interval != "" {
+			var interval time.Duration
+			interval, err = time.ParseDuration(sc.Interval)
+			if err != nil {
+				err = fmt.Errorf("server.%s: lsmon_interval: %s", server, err)
+				return
+			}
+
+			if interval < time.Second {
+				err = fmt.Errorf("server.%s: lsmon_interval must be 1s or more", server)
+				return
+			}
+		}
+
+		if sc.History != 0 && sc.History < 2 {
+			err = fmt.Errorf("server.%s: lsmon_history must be 2 or more", server)
+			return
+		}
+	}
+
+	return
+}
+
+// getInterval is return sampling interval for server.
+func (o *Option) getInterval(server string) (interval time.Duration) {
+	interval = o.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	if sc, ok := o.Config.Server[server]; ok && sc.Interval != "" {
+		if d, err := time.ParseDuration(sc.Interval); err == nil {
+			interval = d
+		}
+	}
+
+	return
+}
+
+// getHistory is return number of samples kept for server.
+func (o *Option) getHistory(server string) (history int) {
+	history = o.History
+	if history <= 0 {
+		history = DefaultHistory
+	}
+
+	if sc, ok := o.Config.Server[server]; ok && sc.History > 0 {
+		history = sc.History
+	}
+
+	return
+}
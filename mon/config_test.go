@@ -0,0 +1,88 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestReadConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  bool
+	}{
+		{"empty", "", false},
+		{"lssh keys", "[server.a]\naddr = \"192.168.0.1\"\nuser = \"user\"\n", false},
+		{"interval and history", "[server.a]\nlsmon_interval = \"10s\"\nlsmon_history = 240\n", false},
+		{"invalid interval", "[server.a]\nlsmon_interval = \"10\"\n", true},
+		{"too short interval", "[server.a]\nlsmon_interval = \"500ms\"\n", true},
+		{"too small history", "[server.a]\nlsmon_history = 1\n", true},
+		{"invalid toml", "[server.a\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadConfig(writeConfig(t, tt.data))
+			if (err != nil) != tt.err {
+				t.Errorf("ReadConfig() error = %v, want error %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestReadConfigNotExist(t *testing.T) {
+	c, err := ReadConfig(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil {
+		t.Fatalf("ReadConfig() error: %v", err)
+	}
+	if c.Server == nil {
+		t.Errorf("Server is nil")
+	}
+}
+
+func TestOptionInterval(t *testing.T) {
+	c, err := ReadConfig(writeConfig(t, "[server.a]\nlsmon_interval = \"10s\"\nlsmon_history = 240\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		option   Option
+		server   string
+		interval time.Duration
+		history  int
+	}{
+		{"default", Option{Config: c}, "b", DefaultInterval, DefaultHistory},
+		{"flag", Option{Config: c, Interval: 5 * time.Second, History: 100}, "b", 5 * time.Second, 100},
+		{"server config", Option{Config: c, Interval: 5 * time.Second, History: 100}, "a", 10 * time.Second, 240},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.option.getInterval(tt.server); got != tt.interval {
+				t.Errorf("getInterval(%q) = %v, want %v", tt.server, got, tt.interval)
+			}
+			if got := tt.option.getHistory(tt.server); got != tt.history {
+				t.Errorf("getHistory(%q) = %d, want %d", tt.server, got, tt.history)
+			}
+		})
+	}
+}
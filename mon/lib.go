@@ -8,15 +8,47 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	sshrun "github.com/blacknon/lssh/ssh"
 	mview "github.com/blacknon/mview"
 )
 
+// LocalServerName is the server name used for monitoring the local machine.
+const LocalServerName = "localhost"
+
+// Option is lsmon option set from command line flags.
+type Option struct {
+	// Backend is the collection backend. BackendSFTP, BackendExec or BackendLocal.
+	Backend string
+
+	// LocalServer is the server name that reads /proc from the local filesystem without SSH.
+	// If empty, all servers are connected by SSH.
+	LocalServer string
+
+	// Interval is the sampling interval. It can be overridden per host by Config.
+	Interval time.Duration
+
+	// History is the number of samples kept per metric. It can be overridden per host by Config.
+	History int
+
+	// NetworkBits is show network rates in bits per second.
+	NetworkBits bool
+
+	// Pressure is show Pressure Stall Information columns in main table.
+	Pressure bool
+
+	// Config is lsmon settings read from the lssh config file.
+	Config Config
+}
+
 type Monitor struct {
 	// selected server list
 	ServerList []string
 
+	// Option
+	Option *Option
+
 	// sshrun.Run
 	r *sshrun.Run
 
@@ -41,9 +73,12 @@ type Monitor struct {
 	sync.Mutex
 }
 
-func Run(r *sshrun.Run) (err error) {
+func Run(r *sshrun.Run, option *Option) (err error) {
 	monitor := Monitor{}
 	monitor.r = r
+	monitor.Option = option
+
+	NetworkBits = option.NetworkBits
 
 	monitor.enableTop = false
 
@@ -86,11 +121,30 @@ func (m *Monitor) GetNode(server string) *Node {
 	return nil
 }
 
+// getMinInterval is return the shortest sampling interval in nodes.
+func (m *Monitor) getMinInterval() (interval time.Duration) {
+	for _, node := range m.Nodes {
+		if interval == 0 || node.Interval < interval {
+			interval = node.Interval
+		}
+	}
+
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+
+	return
+}
+
 func (m *Monitor) CreateNode(server string, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	// node
-	node := NewNode(server)
+	node := NewNode(server, m.Option.getInterval(server), m.Option.getHistory(server))
+	node.Backend = m.Option.Backend
+	if m.Option.LocalServer != "" && server == m.Option.LocalServer {
+		node.Backend = BackendLocal
+	}
 
 	m.Lock()
 	m.Nodes = append(m.Nodes, node)
@@ -8,12 +8,11 @@ import (
 	"fmt"
 	"log"
 	"path/filepath"
-	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	sshproc "github.com/blacknon/go-sshproc"
 	sshrun "github.com/blacknon/lssh/ssh"
 	"github.com/c9s/goprocinfo/linux"
 )
@@ -64,10 +63,30 @@ type DiskUsage struct {
 	Free         uint64
 	ReadIOBytes  []int64
 	WriteIOBytes []int64
+	ReadIOPS     float64
+	WriteIOPS    float64
+}
+
+// DiskHistory is the per second history of a block device.
+type DiskHistory struct {
+	ReadBytes  *Ring[int64]
+	WriteBytes *Ring[int64]
+	ReadIOPS   *Ring[float64]
+	WriteIOPS  *Ring[float64]
+}
+
+func newDiskHistory(size int) *DiskHistory {
+	return &DiskHistory{
+		ReadBytes:  NewRing[int64](size),
+		WriteBytes: NewRing[int64](size),
+		ReadIOPS:   NewRing[float64](size),
+		WriteIOPS:  NewRing[float64](size),
+	}
 }
 
 type DiskIO struct {
 	Device     string
+	Timestamp  time.Time
 	ReadIOs    uint64
 	ReadBytes  int64
 	WriteIOs   uint64
@@ -84,20 +103,94 @@ type NetworkUsage struct {
 	TXPackets   []uint64
 }
 
+// ProcessUsage is monitoring process struct
+type ProcessUsage struct {
+	PID     uint64
+	PPID    int64
+	User    string
+	State   string
+	CPU     float64
+	RSS     uint64
+	VSZ     uint64
+	Threads int64
+	Nice    int64
+	Command string
+}
+
+// NetworkHistory is the per second history of a network device.
+type NetworkHistory struct {
+	RXBytes   *Ring[uint64]
+	TXBytes   *Ring[uint64]
+	RXPackets *Ring[uint64]
+	TXPackets *Ring[uint64]
+}
+
+func newNetworkHistory(size int) *NetworkHistory {
+	return &NetworkHistory{
+		RXBytes:   NewRing[uint64](size),
+		TXBytes:   NewRing[uint64](size),
+		RXPackets: NewRing[uint64](size),
+		TXPackets: NewRing[uint64](size),
+	}
+}
+
 type NetworkIO struct {
 	Device    string
+	Timestamp time.Time
 	RXPackets uint64
 	RXBytes   uint64
 	TXPackets uint64
 	TXBytes   uint64
-	sync.RWMutex
+}
+
+// PressureResources is the resources of Pressure Stall Information. It is the file name in /proc/pressure.
+var PressureResources = []string{"cpu", "memory", "io"}
+
+// PressureUsage is Pressure Stall Information of a resource.
+type PressureUsage struct {
+	Resource string
+	Pressure
+	SomeHistory []float64 // some avg10 history. The oldest is first.
+	FullHistory []float64 // full avg10 history. The oldest is first.
+}
+
+// PressureHistory is the avg10 history of a resource.
+type PressureHistory struct {
+	Latest Pressure
+	Some   *Ring[float64]
+	Full   *Ring[float64]
+}
+
+func newPressureHistory(size int) *PressureHistory {
+	return &PressureHistory{
+		Some: NewRing[float64](size),
+		Full: NewRing[float64](size),
+	}
+}
+
+// CounterReset is the record of a counter that was decreased between samples.
+// The sample is dropped (the rate is recorded as 0), because the delta is not valid.
+type CounterReset struct {
+	Timestamp time.Time
+	Kind      string // cpu, disk, network or uptime
+	Device    string
 }
 
 // Node is monitoring node struct
 type Node struct {
 	ServerName string
 
-	con *sshproc.ConnectWithProc
+	// Backend is the collection backend. BackendSFTP, BackendExec or BackendLocal.
+	Backend string
+
+	src     ProcSource
+	srcLock sync.RWMutex
+
+	// Interval is the sampling interval.
+	Interval time.Duration
+
+	// History is the number of samples kept per metric.
+	History int
 
 	// Path
 	PathProcStat      string
@@ -107,27 +200,49 @@ type Node struct {
 	PathProcLoadavg   string
 	PathProcMounts    string
 	PathProcDiskStats string
+	PathProcVersion   string
+	PathProcNetDev    string
+	PathProcFibTrie   string
+	PathProcRoute     string
+	PathProcIfInet6   string
+	PathProcPressure  string
+	PathProc          string
+	PathSysBlock      string
+	PathEtcPasswd     string
 
 	// CPU Usage
-	cpuUsage      []CPUUsage
-	cpuUsageLimit int
+	cpuUsage *Ring[CPUUsage]
 
 	// DiskIO
-	DiskIOs          map[string][]*DiskIO
-	DiskIOsLimit     int
-	DiskReadIOBytes  []int64
-	DiskWriteIOBytes []int64
+	// DiskIOs and DiskHistories are keyed by the kernel name of block device (ex: sda1, dm-0).
+	DiskIOs       map[string]*Ring[DiskIO]
+	DiskHistories map[string]*DiskHistory
+	diskDevices   map[string]string // device path => kernel name
 
 	// NetworkIO
-	NetworkIOs       map[string][]*NetworkIO
-	NetworkIOsLimit  int
-	NetworkRXBytes   []uint64
-	NetworkRXPackets []uint64
-	NetworkTXBytes   []uint64
-	NetworkTXPackets []uint64
+	NetworkIOs       map[string]*Ring[NetworkIO]
+	NetworkHistories map[string]*NetworkHistory
+
+	// Pressure. keyed by resource (cpu, memory, io). The resource is not exist when PSI is not supported.
+	pressures map[string]*PressureHistory
+
+	// Counter resets. uptime is the uptime(seconds) read at last tick, used to detect reboot.
+	counterResets *Ring[CounterReset]
+	uptime        float64
+
+	// Mount points read at last GetDiskUsage. used to prefetch statfs.
+	mountPoints []string
 
 	// Process
 	LatestProcessLists []*linux.Process
+	processUsages      []*ProcessUsage
+	processCPUTimes    map[uint64]uint64
+	processCPUTotal    float64
+	userNames          map[uint64]string
+
+	// Snapshot
+	snapshot      *Snapshot
+	snapshotFuncs []SnapshotFunc
 
 	// Top
 	NodeTop *NodeTop
@@ -137,14 +252,22 @@ type Node struct {
 
 // NewNode is create new Node struct.
 // with set default values
-func NewNode(name string) *Node {
-	//
-	procConnect := &sshproc.ConnectWithProc{Connect: nil}
+func NewNode(name string, interval time.Duration, history int) *Node {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	if history < 2 {
+		history = DefaultHistory
+	}
 
 	node := &Node{
 		ServerName: name,
 
-		con: procConnect,
+		Backend: BackendSFTP,
+
+		Interval: interval,
+		History:  history,
 
 		// set default path
 		PathProcStat:      "/proc/stat",
@@ -154,18 +277,37 @@ func NewNode(name string) *Node {
 		PathProcLoadavg:   "/proc/loadavg",
 		PathProcMounts:    "/proc/mounts",
 		PathProcDiskStats: "/proc/diskstats",
+		PathProcVersion:   "/proc/version",
+		PathProcNetDev:    "/proc/net/dev",
+		PathProcFibTrie:   "/proc/net/fib_trie",
+		PathProcRoute:     "/proc/net/route",
+		PathProcIfInet6:   "/proc/net/if_inet6",
+		PathProcPressure:  "/proc/pressure",
+		PathProc:          "/proc",
+		PathSysBlock:      "/sys/block",
+		PathEtcPasswd:     "/etc/passwd",
 
 		// CPU Usage
-		cpuUsage:      []CPUUsage{},
-		cpuUsageLimit: 480,
+		cpuUsage: NewRing[CPUUsage](history),
 
 		// DiskIO
-		DiskIOs:      map[string][]*DiskIO{},
-		DiskIOsLimit: 480,
+		DiskIOs:       map[string]*Ring[DiskIO]{},
+		DiskHistories: map[string]*DiskHistory{},
+		diskDevices:   map[string]string{},
 
 		// NetworkIO
-		NetworkIOs:      map[string][]*NetworkIO{},
-		NetworkIOsLimit: 480,
+		NetworkIOs:       map[string]*Ring[NetworkIO]{},
+		NetworkHistories: map[string]*NetworkHistory{},
+
+		// Pressure
+		pressures: map[string]*PressureHistory{},
+
+		// Counter resets
+		counterResets: NewRing[CounterReset](history),
+
+		// Process
+		processCPUTimes: map[uint64]uint64{},
+		userNames:       map[uint64]string{},
 	}
 
 	// Create Top
@@ -175,39 +317,60 @@ func NewNode(name string) *Node {
 }
 
 func (n *Node) CheckClientAlive() bool {
-	if n.con.Connect == nil {
+	src := n.getSource()
+	if src == nil {
 		return false
 	}
 
-	return n.con.CheckSftpClient()
+	return src.CheckAlive()
+}
+
+// getSource is return the current ProcSource. It is replaced by Connect at reconnect.
+func (n *Node) getSource() ProcSource {
+	n.srcLock.RLock()
+	defer n.srcLock.RUnlock()
+
+	return n.src
+}
+
+func (n *Node) setSource(src ProcSource) {
+	n.srcLock.Lock()
+	defer n.srcLock.Unlock()
+
+	n.src = src
 }
 
 func (n *Node) Connect(r *sshrun.Run) (err error) {
+	// local machine is read without ssh
+	if n.Backend == BackendLocal {
+		n.setSource(NewLocalSource())
+		return
+	}
+
 	// Create *sshlib.Connect
 	con, err := r.CreateSshConnect(n.ServerName)
 	if err != nil {
 		log.Printf("CreateSshConnect %s Error: %s", n.ServerName, err)
-		n.con.Connect = nil
+		n.setSource(nil)
 		return
 	}
 
 	// Create Session and run KeepAlive
 	con.SendKeepAliveInterval = 10
 
-	procCon := &sshproc.ConnectWithProc{Connect: con}
-	err = procCon.CreateSftpClient()
+	src, err := NewProcSource(n.Backend, con)
 	if err != nil {
-		log.Printf("CreateSftpClient %s Error: %s", n.ServerName, err)
-		n.con.Connect = nil
+		log.Printf("NewProcSource %s Error: %s", n.ServerName, err)
+		n.setSource(nil)
 		return
 	}
 
-	n.con = procCon
+	n.setSource(src)
 	session, err := con.CreateSession()
 	if err != nil {
 		log.Printf("CreateSession %s Error: %s", n.ServerName, err)
-		n.con.Connect = nil
-		procCon.CloseSftpClient()
+		n.setSource(nil)
+		src.Close()
 		return
 	}
 
@@ -216,9 +379,9 @@ func (n *Node) Connect(r *sshrun.Run) (err error) {
 		log.Println("Start KeepAlive. Server:", n.ServerName)
 		con.SendKeepAlive(session)
 
-		// close sftp client
+		// close source
 		session.Close()
-		err := procCon.CloseSftpClient()
+		err := src.Close()
 		if err != nil {
 			log.Printf("CloseSession Error: %s", err)
 		}
@@ -227,13 +390,23 @@ func (n *Node) Connect(r *sshrun.Run) (err error) {
 	return
 }
 
+// getGraphCount is return the number of points drawn in graph.
+// It does not exceed the number of samples kept in history.
+func (n *Node) getGraphCount(count int) int {
+	if count > n.History {
+		return n.History
+	}
+
+	return count
+}
+
 // GetCPUCore is get cpu core num
 func (n *Node) GetCPUCore() (cn int, err error) {
 	if !n.CheckClientAlive() {
 		return
 	}
 
-	cpuinfo, err := n.con.ReadCPUInfo(n.PathProcCpuinfo)
+	cpuinfo, err := readCPUInfo(n.getSource(), n.PathProcCpuinfo)
 	if err != nil {
 		return
 	}
@@ -242,198 +415,37 @@ func (n *Node) GetCPUCore() (cn int, err error) {
 	return
 }
 
-func (n *Node) GetCPUUsage() (usage float64, err error) {
-	if !n.CheckClientAlive() {
-		return
-	}
-
-	usage = 0.0
-	if len(n.cpuUsage) >= 2 {
-		lUsage := n.cpuUsage[len(n.cpuUsage)-1]
-		pUsage := n.cpuUsage[len(n.cpuUsage)-2]
-
-		// Get total usage
-		lUsageTotal := sumFloat64(
-			float64(lUsage.User),
-			float64(lUsage.Nice),
-			float64(lUsage.System),
-			float64(lUsage.Idle),
-			float64(lUsage.IOWait),
-			float64(lUsage.IRQ),
-			float64(lUsage.SoftIRQ),
-			float64(lUsage.Steal),
-			float64(lUsage.Guest),
-			float64(lUsage.GuestNice),
-		)
-
-		pUsageTotal := sumFloat64(
-			float64(pUsage.User),
-			float64(pUsage.Nice),
-			float64(pUsage.System),
-			float64(pUsage.Idle),
-			float64(pUsage.IOWait),
-			float64(pUsage.IRQ),
-			float64(pUsage.SoftIRQ),
-			float64(pUsage.Steal),
-			float64(pUsage.Guest),
-			float64(pUsage.GuestNice),
-		)
-
-		// Get idle
-		lIdle := float64(lUsage.Idle)
-		pIdle := float64(pUsage.Idle)
+// GetCPUUsages is get cpu usage(%) history. The latest is first.
+// If the history is not enough, it is filled with 0.
+func (n *Node) GetCPUUsages(count int) (usages []float64) {
+	cpuUsages := n.cpuUsage.Latest(count + 1)
 
-		// Get diff total
-		totalDiff := lUsageTotal - pUsageTotal
-		idleDiff := lIdle - pIdle
+	usages = []float64{}
+	for i := 1; i <= count; i++ {
+		l := len(cpuUsages) - i
+		p := l - 1
 
-		usage = (totalDiff - idleDiff) / totalDiff * 100
-	}
-
-	return
-}
-
-func (n *Node) GetCPUUsageWithSparkline() (usage float64, sparkline string, err error) {
-	if !n.CheckClientAlive() {
-		return
-	}
-
-	usages := []float64{}
-	sparklineNums := 11
-
-	for i := 1; i < sparklineNums; i++ {
-		l := i
-		p := i + 1
-
-		if len(n.cpuUsage) < p+1 {
+		if p < 0 {
 			usages = append(usages, 0.0)
 			continue
 		}
 
-		lUsage := n.cpuUsage[len(n.cpuUsage)-l]
-		pUsage := n.cpuUsage[len(n.cpuUsage)-p]
-
-		// Get total usage
-		lUsageTotal := sumFloat64(
-			float64(lUsage.User),
-			float64(lUsage.Nice),
-			float64(lUsage.System),
-			float64(lUsage.Idle),
-			float64(lUsage.IOWait),
-			float64(lUsage.IRQ),
-			float64(lUsage.SoftIRQ),
-			float64(lUsage.Steal),
-			float64(lUsage.Guest),
-			float64(lUsage.GuestNice),
-		)
-
-		pUsageTotal := sumFloat64(
-			float64(pUsage.User),
-			float64(pUsage.Nice),
-			float64(pUsage.System),
-			float64(pUsage.Idle),
-			float64(pUsage.IOWait),
-			float64(pUsage.IRQ),
-			float64(pUsage.SoftIRQ),
-			float64(pUsage.Steal),
-			float64(pUsage.Guest),
-			float64(pUsage.GuestNice),
-		)
-
-		// Get idle
-		lIdle := float64(lUsage.Idle)
-		pIdle := float64(pUsage.Idle)
+		lUsage := cpuUsages[l]
+		pUsage := cpuUsages[p]
 
 		// Get diff total
-		totalDiff := lUsageTotal - pUsageTotal
-		idleDiff := lIdle - pIdle
+		totalDiff := cpuStatTotal(lUsage.CPUStat) - cpuStatTotal(pUsage.CPUStat)
+		idleDiff := float64(lUsage.Idle) - float64(pUsage.Idle)
 
-		usages = append(usages, (totalDiff-idleDiff)/totalDiff*100)
-	}
-
-	usage = usages[0]
-	sparkline = ""
-	if len(usages) > 2 {
-		graph := Graph{
-			Data: usages,
-			Max:  100,
-			Min:  0,
-		}
-
-		sparkline = strings.Join(graph.Sparkline(), "")
-	}
-
-	return
-}
-
-func (n *Node) GetCPUUsageWithBrailleLine() (usage float64, brailleLine string, err error) {
-	if !n.CheckClientAlive() {
-		return
-	}
-
-	usages := []float64{}
-	for i := 1; i < 22; i++ {
-		l := i
-		p := i + 1
-
-		if len(n.cpuUsage) < p+1 {
+		// /proc/stat was not changed between samples
+		if totalDiff <= 0 {
 			usages = append(usages, 0.0)
 			continue
 		}
 
-		lUsage := n.cpuUsage[len(n.cpuUsage)-l]
-		pUsage := n.cpuUsage[len(n.cpuUsage)-p]
-
-		// Get total usage
-		lUsageTotal := sumFloat64(
-			float64(lUsage.User),
-			float64(lUsage.Nice),
-			float64(lUsage.System),
-			float64(lUsage.Idle),
-			float64(lUsage.IOWait),
-			float64(lUsage.IRQ),
-			float64(lUsage.SoftIRQ),
-			float64(lUsage.Steal),
-			float64(lUsage.Guest),
-			float64(lUsage.GuestNice),
-		)
-
-		pUsageTotal := sumFloat64(
-			float64(pUsage.User),
-			float64(pUsage.Nice),
-			float64(pUsage.System),
-			float64(pUsage.Idle),
-			float64(pUsage.IOWait),
-			float64(pUsage.IRQ),
-			float64(pUsage.SoftIRQ),
-			float64(pUsage.Steal),
-			float64(pUsage.Guest),
-			float64(pUsage.GuestNice),
-		)
-
-		// Get idle
-		lIdle := float64(lUsage.Idle)
-		pIdle := float64(pUsage.Idle)
-
-		// Get diff total
-		totalDiff := lUsageTotal - pUsageTotal
-		idleDiff := lIdle - pIdle
-
 		usages = append(usages, (totalDiff-idleDiff)/totalDiff*100)
 	}
 
-	usage = usages[0]
-	brailleLine = ""
-	if len(usages) > 0 {
-		graph := Graph{
-			Data: usages,
-			Max:  100,
-			Min:  0,
-		}
-
-		brailleLine = strings.Join(graph.BrailleLine(), "")
-	}
-
 	return
 }
 
@@ -443,11 +455,12 @@ func (n *Node) GetCPUCoreUsage() (usages []CPUUsageTop, err error) {
 	}
 
 	usages = []CPUUsageTop{}
-	if len(n.cpuUsage) >= 2 {
-		lUsage := n.cpuUsage[len(n.cpuUsage)-1]
-		pUsage := n.cpuUsage[len(n.cpuUsage)-2]
+	cpuUsages := n.cpuUsage.Latest(2)
+	if len(cpuUsages) >= 2 {
+		lUsage := cpuUsages[1]
+		pUsage := cpuUsages[0]
 
-		for i := 0; i < len(lUsage.Detail); i++ {
+		for i := 0; i < len(lUsage.Detail) && i < len(pUsage.Detail); i++ {
 			l := lUsage.Detail[i]
 			p := pUsage.Detail[i]
 
@@ -485,12 +498,18 @@ func (n *Node) GetCPUCoreUsage() (usages []CPUUsageTop, err error) {
 			totalDiff := lUsageTotal - pUsageTotal
 			idleDiff := lIdle - pIdle
 
+			// /proc/stat was not changed between samples
+			if totalDiff <= 0 {
+				usages = append(usages, CPUUsageTop{})
+				continue
+			}
+
 			usage := CPUUsageTop{
-				Low:    float64(l.Nice-p.Nice) / totalDiff,
-				Normal: float64(l.User-p.User) / totalDiff,
-				Kernel: float64(l.System-p.System) / totalDiff,
-				Guest:  float64(l.Guest-p.Guest) / totalDiff,
-				Total:  float64((totalDiff - idleDiff) / totalDiff),
+				Low:    (float64(l.Nice) - float64(p.Nice)) / totalDiff,
+				Normal: (float64(l.User) - float64(p.User)) / totalDiff,
+				Kernel: (float64(l.System) - float64(p.System)) / totalDiff,
+				Guest:  (float64(l.Guest) - float64(p.Guest)) / totalDiff,
+				Total:  (totalDiff - idleDiff) / totalDiff,
 			}
 
 			usages = append(usages, usage)
@@ -500,36 +519,13 @@ func (n *Node) GetCPUCoreUsage() (usages []CPUUsageTop, err error) {
 	return
 }
 
-// GetMemoryUsage is get memory usage. return size is byte.
-func (n *Node) GetMemoryUsage() (memUsed, memTotal, swapUsed, swapTotal uint64, err error) {
-	if !n.CheckClientAlive() {
-		err = fmt.Errorf("Node is not connected")
-		return
-	}
-
-	meminfo, err := n.con.ReadMemInfo(n.PathProcMeminfo)
-	if err != nil {
-		return
-	}
-
-	// memory
-	memUsed = (meminfo.MemTotal - meminfo.MemFree - meminfo.Buffers - meminfo.Cached) * 1024
-	memTotal = (meminfo.MemTotal) * 1024
-
-	// swap
-	swapUsed = (meminfo.SwapTotal - meminfo.SwapFree) * 1024
-	swapTotal = (meminfo.SwapTotal) * 1024
-
-	return
-}
-
 func (n *Node) GetMemInfo() (memInfo *linux.MemInfo, err error) {
 	if !n.CheckClientAlive() {
 		err = fmt.Errorf("Node is not connected")
 		return
 	}
 
-	memInfo, err = n.con.ReadMemInfo(n.PathProcMeminfo)
+	memInfo, err = readMemInfo(n.getSource(), n.PathProcMeminfo)
 	return
 }
 
@@ -539,7 +535,7 @@ func (n *Node) GetKernelVersion() (version string, err error) {
 		return
 	}
 
-	data, err := n.con.ReadData("/proc/version")
+	data, err := readString(n.getSource(), n.PathProcVersion)
 	if err != nil {
 		return
 	}
@@ -561,7 +557,7 @@ func (n *Node) GetUptime() (uptime *linux.Uptime, err error) {
 		return
 	}
 
-	uptime, err = n.con.ReadUptime(n.PathProcUptime)
+	uptime, err = readUptime(n.getSource(), n.PathProcUptime)
 	return
 }
 
@@ -571,7 +567,7 @@ func (n *Node) GetTaskCounts() (tasks uint64, err error) {
 		return
 	}
 
-	processList, err := n.con.ListInPID("/proc")
+	processList, err := listPID(n.getSource(), n.PathProc)
 	if err != nil {
 		return
 	}
@@ -587,49 +583,58 @@ func (n *Node) GetLoadAvg() (loadavg *linux.LoadAvg, err error) {
 		return
 	}
 
-	loadavg, err = n.con.ReadLoadAvg(n.PathProcLoadavg)
+	loadavg, err = readLoadAvg(n.getSource(), n.PathProcLoadavg)
 
 	return
 }
 
 func (n *Node) GetDiskUsage() (diskUsages []*DiskUsage, err error) {
-	if n.con.Connect == nil {
-		return
-	}
-
 	if !n.CheckClientAlive() {
 		return
 	}
 
-	mounts, err := n.con.ReadMounts(n.PathProcMounts)
+	mounts, err := readMounts(n.getSource(), n.PathProcMounts)
 	if err != nil {
 		return
 	}
 
+	mountPoints := []string{}
 	for _, m := range mounts.Mounts {
-		disk, err := n.con.ReadDisk(m.MountPoint)
-		if err != nil {
+		if !fstype[m.FSType] {
 			continue
 		}
+		mountPoints = append(mountPoints, m.MountPoint)
 
-		if fstype[m.FSType] {
-			n.getDiskIOBytes(m.Device)
+		disk, err := n.getSource().StatFS(m.MountPoint)
+		if err != nil {
+			continue
+		}
 
-			diskUsage := &DiskUsage{
-				MountPoint:   m.MountPoint,
-				FSType:       m.FSType,
-				Device:       m.Device,
-				All:          disk.All,
-				Used:         disk.Used,
-				Free:         disk.Free,
-				ReadIOBytes:  n.DiskReadIOBytes,
-				WriteIOBytes: n.DiskWriteIOBytes,
-			}
+		diskUsage := &DiskUsage{
+			MountPoint: m.MountPoint,
+			FSType:     m.FSType,
+			Device:     m.Device,
+			All:        disk.All,
+			Used:       disk.Used,
+			Free:       disk.Free,
+		}
 
-			diskUsages = append(diskUsages, diskUsage)
+		n.RLock()
+		if history := n.getDiskHistory(m.Device); history != nil {
+			diskUsage.ReadIOBytes = history.ReadBytes.Values()
+			diskUsage.WriteIOBytes = history.WriteBytes.Values()
+			diskUsage.ReadIOPS, _ = history.ReadIOPS.Last(0)
+			diskUsage.WriteIOPS, _ = history.WriteIOPS.Last(0)
 		}
+		n.RUnlock()
+
+		diskUsages = append(diskUsages, diskUsage)
 	}
 
+	n.Lock()
+	n.mountPoints = mountPoints
+	n.Unlock()
+
 	return
 }
 
@@ -639,49 +644,46 @@ func (n *Node) GetNetworkUsage() (networkUsages []*NetworkUsage, err error) {
 		return
 	}
 
-	if n.con.Connect == nil {
-		return
+	// Get Network stats
+	networkIOs := make(map[string]*Ring[NetworkIO])
+	n.RLock()
+	for device, networkIO := range n.NetworkIOs {
+		networkIOs[device] = networkIO
 	}
+	n.RUnlock()
 
-	// Get Network stats
-	if len(n.NetworkIOs) == 0 {
+	if len(networkIOs) == 0 {
 		err = fmt.Errorf("NetworkIOs is not found")
 		return
 	}
 
-	networkIOs := make(map[string][]*NetworkIO)
-	n.Lock()
-	for device, networkIO := range n.NetworkIOs {
-		networkIOs[device] = networkIO
-	}
-	n.Unlock()
 	for device, networkIO := range networkIOs {
-		if len(networkIO) > 1 {
-			n.getNetworkIO(device)
+		if networkIO.Len() > 1 {
+			history := n.getNetworkIO(device)
+			if history == nil {
+				continue
+			}
 
 			networkUsage := &NetworkUsage{
 				Device:    device,
-				RXBytes:   n.NetworkRXBytes,
-				TXBytes:   n.NetworkTXBytes,
-				RXPackets: n.NetworkRXPackets,
-				TXPackets: n.NetworkTXPackets,
+				RXBytes:   history.RXBytes.Values(),
+				TXBytes:   history.TXBytes.Values(),
+				RXPackets: history.RXPackets.Values(),
+				TXPackets: history.TXPackets.Values(),
 			}
 
 			networkUsages = append(networkUsages, networkUsage)
 		}
 	}
 
-	for _, networkUsage := range networkUsages {
-		ipv4, err := n.GetIPv4()
-		if err != nil {
-			continue
-		}
-
-		ipv6, err := n.GetIPV6()
-		if err != nil {
-			continue
-		}
+	// Get IP Address
+	ipv4, ipv4Err := n.GetIPv4()
+	ipv6, ipv6Err := n.GetIPV6()
+	if ipv4Err != nil || ipv6Err != nil {
+		return
+	}
 
+	for _, networkUsage := range networkUsages {
 		for _, ip := range ipv4 {
 			if ip.Interface == networkUsage.Device {
 				ipAddress := ip.IPAddress
@@ -704,13 +706,13 @@ func (n *Node) GetNetworkUsage() (networkUsages []*NetworkUsage, err error) {
 	return
 }
 
-func (n *Node) GetIPv4() (ipv4 []sshproc.IPv4, err error) {
+func (n *Node) GetIPv4() (ipv4 []IPv4, err error) {
 	if !n.CheckClientAlive() {
 		err = fmt.Errorf("Node is not connected")
 		return
 	}
 
-	ipv4, err = n.con.ReadFibTrie("/proc/net/fib_trie", "/proc/net/route")
+	ipv4, err = readFibTrie(n.getSource(), n.PathProcFibTrie, n.PathProcRoute)
 	if err != nil {
 		return
 	}
@@ -718,13 +720,13 @@ func (n *Node) GetIPv4() (ipv4 []sshproc.IPv4, err error) {
 	return
 }
 
-func (n *Node) GetIPV6() (ipv6 []sshproc.IPv6, err error) {
+func (n *Node) GetIPV6() (ipv6 []IPv6, err error) {
 	if !n.CheckClientAlive() {
 		err = fmt.Errorf("Node is not connected")
 		return
 	}
 
-	ipv6, err = n.con.ReadIfInet6("/proc/net/if_inet6")
+	ipv6, err = readIfInet6(n.getSource(), n.PathProcIfInet6)
 	if err != nil {
 		return
 	}
@@ -732,18 +734,92 @@ func (n *Node) GetIPV6() (ipv6 []sshproc.IPv6, err error) {
 	return
 }
 
+// GetPressureUsage is get Pressure Stall Information collected by MonitoringPressure.
+// pressureUsages is empty when PSI is not supported.
+func (n *Node) GetPressureUsage() (pressureUsages []*PressureUsage, err error) {
+	if !n.CheckClientAlive() {
+		err = fmt.Errorf("Node is not connected")
+		return
+	}
+
+	n.RLock()
+	defer n.RUnlock()
+
+	for _, resource := range PressureResources {
+		history, ok := n.pressures[resource]
+		if !ok {
+			continue
+		}
+
+		pressureUsage := &PressureUsage{
+			Resource:    resource,
+			Pressure:    history.Latest,
+			SomeHistory: history.Some.Values(),
+			FullHistory: history.Full.Values(),
+		}
+
+		pressureUsages = append(pressureUsages, pressureUsage)
+	}
+
+	return
+}
+
+// GetProcessUsage is get the process list collected by MonitoringProcess.
+func (n *Node) GetProcessUsage() (processUsages []*ProcessUsage, err error) {
+	if !n.CheckClientAlive() {
+		err = fmt.Errorf("Node is not connected")
+		return
+	}
+
+	n.RLock()
+	defer n.RUnlock()
+
+	processUsages = make([]*ProcessUsage, len(n.processUsages))
+	copy(processUsages, n.processUsages)
+
+	return
+}
+
+// getUserName is get user name from uid. /etc/passwd is read only once per connection.
+func (n *Node) getUserName(uid uint64) string {
+	n.Lock()
+	defer n.Unlock()
+
+	if len(n.userNames) == 0 {
+		data, err := readString(n.getSource(), n.PathEtcPasswd)
+		if err == nil {
+			for _, line := range strings.Split(data, "\n") {
+				fields := strings.Split(line, ":")
+				if len(fields) < 3 {
+					continue
+				}
+
+				id, err := strconv.ParseUint(fields[2], 10, 64)
+				if err != nil {
+					continue
+				}
+				n.userNames[id] = fields[0]
+			}
+		}
+	}
+
+	if name, ok := n.userNames[uid]; ok {
+		return name
+	}
+
+	return strconv.FormatUint(uid, 10)
+}
+
 func (n *Node) MonitoringCPUUsage() {
 	if !n.CheckClientAlive() {
 		// reset cpuUsage
-		n.Lock()
-		n.cpuUsage = []CPUUsage{}
-		n.Unlock()
+		n.cpuUsage.Reset()
 
 		return
 	}
 
 	timestamp := time.Now()
-	stat, err := n.con.ReadStat(n.PathProcStat)
+	stat, err := readStat(n.getSource(), n.PathProcStat)
 	if err != nil {
 		return
 	}
@@ -755,50 +831,54 @@ func (n *Node) MonitoringCPUUsage() {
 		timestamp,
 	}
 
-	n.cpuUsage = append(n.cpuUsage, cpuUsage)
-	if len(n.cpuUsage) > n.cpuUsageLimit {
-		n.Lock()
-		n.cpuUsage = n.cpuUsage[1:]
-		n.Unlock()
+	// cpu time is decreased. the previous samples can not be compared.
+	if pUsage, ok := n.cpuUsage.Last(0); ok && cpuStatTotal(cpuUsage.CPUStat) < cpuStatTotal(pUsage.CPUStat) {
+		n.recordCounterReset("cpu", stat.CPUStatAll.Id)
+		n.cpuUsage.Reset()
 	}
+
+	n.cpuUsage.Push(cpuUsage)
 }
 
 func (n *Node) MonitoringDiskIO() {
 	if !n.CheckClientAlive() {
 		n.Lock()
-		n.DiskIOs = map[string][]*DiskIO{}
+		n.DiskIOs = map[string]*Ring[DiskIO]{}
+		n.DiskHistories = map[string]*DiskHistory{}
+		n.diskDevices = map[string]string{}
 		n.Unlock()
 		return
 	}
 
 	// Get Disk stats
-	stats, err := n.con.ReadDiskStats(n.PathProcDiskStats)
+	timestamp := time.Now()
+	stats, err := readDiskStats(n.getSource(), n.PathProcDiskStats)
 	if err != nil {
 		return
 	}
 
 	// Get Disk IO
+	devices := map[string]string{}
 	for _, stat := range stats {
-		device := ""
-
-		// if mapper device, get /dev path
-		match, _ := regexp.MatchString("^md-", device)
-		if match {
-			sysDeviceName := fmt.Sprintf("/sys/block/%s/dm/name", stat.Name)
-			mapperDeviceName, err := n.con.ReadData(sysDeviceName)
-			if err != nil {
-				continue
+		name := stat.Name
+		device := filepath.Join("/dev", name)
+		devices[device] = name
+
+		// device-mapper(LVM, dm-crypt, ...) is mounted as /dev/mapper/<name>
+		if strings.HasPrefix(name, "dm-") {
+			sysDeviceName := filepath.Join(n.PathSysBlock, name, "dm", "name")
+			mapperDeviceName, err := readString(n.getSource(), sysDeviceName)
+			mapperDeviceName = strings.TrimSpace(mapperDeviceName)
+			if err == nil && mapperDeviceName != "" {
+				device = filepath.Join("/dev/mapper", mapperDeviceName)
+				devices[device] = name
 			}
-
-			// overwrite device
-			device = filepath.Join("/dev/mapper", mapperDeviceName)
-		} else {
-			device = fmt.Sprintf("/dev/%s", stat.Name)
 		}
 
 		// Get Disk IO
 		diskIO := DiskIO{
 			Device:     device,
+			Timestamp:  timestamp,
 			ReadIOs:    stat.ReadIOs,
 			ReadBytes:  stat.GetReadBytes(),
 			WriteIOs:   stat.WriteIOs,
@@ -806,27 +886,40 @@ func (n *Node) MonitoringDiskIO() {
 		}
 
 		n.Lock()
-		n.DiskIOs[device] = append(n.DiskIOs[device], &diskIO)
-		n.Unlock()
+		diskIOs, ok := n.DiskIOs[name]
+		if !ok {
+			diskIOs = NewRing[DiskIO](n.History)
+			n.DiskIOs[name] = diskIOs
+		}
 
-		if len(n.DiskIOs[device]) > n.DiskIOsLimit {
-			n.Lock()
-			n.DiskIOs[device] = n.DiskIOs[device][1:]
-			n.Unlock()
+		history, ok := n.DiskHistories[name]
+		if !ok {
+			history = newDiskHistory(n.History)
+			n.DiskHistories[name] = history
 		}
+		n.Unlock()
+
+		diskIOs.Push(diskIO)
+		n.updateDiskHistory(diskIOs, history)
 	}
+
+	n.Lock()
+	n.diskDevices = devices
+	n.Unlock()
 }
 
 func (n *Node) MonitoringNetworkIO() (err error) {
 	if !n.CheckClientAlive() {
 		n.Lock()
-		n.NetworkIOs = map[string][]*NetworkIO{}
+		n.NetworkIOs = map[string]*Ring[NetworkIO]{}
+		n.NetworkHistories = map[string]*NetworkHistory{}
 		n.Unlock()
 		return
 	}
 
 	// Get Network stats
-	stats, err := n.con.ReadNetworkStat("/proc/net/dev")
+	timestamp := time.Now()
+	stats, err := readNetworkStat(n.getSource(), n.PathProcNetDev)
 	if err != nil {
 		return
 	}
@@ -835,6 +928,7 @@ func (n *Node) MonitoringNetworkIO() (err error) {
 	for _, stat := range stats {
 		networkIO := NetworkIO{
 			Device:    stat.Iface,
+			Timestamp: timestamp,
 			RXPackets: stat.RxPackets,
 			RXBytes:   stat.RxBytes,
 			TXPackets: stat.TxPackets,
@@ -842,138 +936,343 @@ func (n *Node) MonitoringNetworkIO() (err error) {
 		}
 
 		n.Lock()
-		n.NetworkIOs[stat.Iface] = append(n.NetworkIOs[stat.Iface], &networkIO)
+		networkIOs, ok := n.NetworkIOs[stat.Iface]
+		if !ok {
+			networkIOs = NewRing[NetworkIO](n.History)
+			n.NetworkIOs[stat.Iface] = networkIOs
+		}
 		n.Unlock()
 
-		if len(n.NetworkIOs[stat.Iface]) > n.NetworkIOsLimit {
-			n.Lock()
-			n.NetworkIOs[stat.Iface] = n.NetworkIOs[stat.Iface][1:]
-			n.Unlock()
-		}
+		networkIOs.Push(networkIO)
 	}
 	return
 }
 
-func (n *Node) StartMonitoring() {
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
+func (n *Node) MonitoringPressure() {
+	if !n.CheckClientAlive() {
+		n.Lock()
+		n.pressures = map[string]*PressureHistory{}
+		n.Unlock()
+		return
+	}
 
-	for range ticker.C {
-		n.MonitoringCPUUsage()
-		n.MonitoringDiskIO()
-		n.MonitoringNetworkIO()
+	for _, resource := range PressureResources {
+		pressure, err := readPressure(n.getSource(), filepath.Join(n.PathProcPressure, resource))
+
+		n.Lock()
+		if err != nil {
+			// PSI is not supported (before Linux 4.20, or disabled by psi=0)
+			delete(n.pressures, resource)
+			n.Unlock()
+			continue
+		}
+
+		history, ok := n.pressures[resource]
+		if !ok {
+			history = newPressureHistory(n.History)
+			n.pressures[resource] = history
+		}
+		history.Latest = *pressure
+		n.Unlock()
+
+		history.Some.Push(pressure.Some.Avg10)
+		history.Full.Push(pressure.Full.Avg10)
 	}
 }
 
-func (n *Node) getDiskIOBytes(device string) {
-	n.Lock()
-	defer n.Unlock()
+func (n *Node) MonitoringProcess() {
+	if !n.CheckClientAlive() {
+		n.Lock()
+		n.LatestProcessLists = []*linux.Process{}
+		n.processUsages = []*ProcessUsage{}
+		n.processCPUTimes = map[uint64]uint64{}
+		n.processCPUTotal = 0
+		n.userNames = map[uint64]string{}
+		n.Unlock()
+		return
+	}
 
-	if len(device) == 0 {
+	// Get cpu total time. used to calculate process cpu usage.
+	lUsage, ok := n.cpuUsage.Last(0)
+	if !ok {
 		return
 	}
 
-	diskIO := n.DiskIOs[device]
-	if len(diskIO) > 1 {
-		var readIOBytes int64
-		var writeIOBytes int64
+	cpuTotal := cpuStatTotal(lUsage.CPUStat)
+	cpuCores := len(lUsage.Detail)
+	if cpuCores == 0 {
+		cpuCores = 1
+	}
 
-		preReadIOBytes := diskIO[len(diskIO)-2].ReadBytes
-		preWriteIOBytes := diskIO[len(diskIO)-2].WriteBytes
+	n.RLock()
+	elapsed := (cpuTotal - n.processCPUTotal) / float64(cpuCores)
+	preCPUTimes := n.processCPUTimes
+	n.RUnlock()
+
+	// Get pid list
+	pids, err := listPID(n.getSource(), n.PathProc)
+	if err != nil {
+		return
+	}
 
-		readIOBytes = diskIO[len(diskIO)-1].ReadBytes - preReadIOBytes
-		writeIOBytes = diskIO[len(diskIO)-1].WriteBytes - preWriteIOBytes
+	processLists := []*linux.Process{}
+	processUsages := []*ProcessUsage{}
+	cpuTimes := map[uint64]uint64{}
+	for _, pid := range pids {
+		pp := filepath.Join(n.PathProc, strconv.FormatUint(pid, 10))
 
-		if len(n.DiskReadIOBytes) == 0 {
-			n.DiskReadIOBytes = append(n.DiskReadIOBytes, 0)
-		} else {
-			n.DiskReadIOBytes = append(n.DiskReadIOBytes, readIOBytes)
+		// process may exit while reading, so skip it.
+		stat, err := readProcessStat(n.getSource(), filepath.Join(pp, "stat"))
+		if err != nil {
+			continue
 		}
 
-		if len(n.DiskWriteIOBytes) == 0 {
-			n.DiskWriteIOBytes = append(n.DiskWriteIOBytes, 0)
-		} else {
-			n.DiskWriteIOBytes = append(n.DiskWriteIOBytes, writeIOBytes)
+		process := &linux.Process{Stat: *stat}
+
+		status, err := readProcessStatus(n.getSource(), filepath.Join(pp, "status"))
+		if err == nil {
+			process.Status = *status
 		}
-	}
 
-	if len(n.DiskReadIOBytes) > n.DiskIOsLimit {
-		n.DiskReadIOBytes = n.DiskReadIOBytes[1:]
-	}
+		cmdline, err := readProcessCmdline(n.getSource(), filepath.Join(pp, "cmdline"))
+		if err == nil {
+			process.Cmdline = cmdline
+		}
 
-	if len(n.DiskWriteIOBytes) > n.DiskIOsLimit {
-		n.DiskWriteIOBytes = n.DiskWriteIOBytes[1:]
-	}
+		// kernel thread has not cmdline
+		command := process.Cmdline
+		if command == "" {
+			command = fmt.Sprintf("[%s]", strings.Trim(stat.Comm, "()"))
+		}
 
-	return
-}
+		// cpu usage
+		cpuTime := stat.Utime + stat.Stime
+		cpuTimes[pid] = cpuTime
+
+		cpu := 0.0
+		if preCPUTime, ok := preCPUTimes[pid]; ok && elapsed > 0 && cpuTime >= preCPUTime {
+			cpu = float64(cpuTime-preCPUTime) / elapsed * 100
+		}
+
+		processUsage := &ProcessUsage{
+			PID:     pid,
+			PPID:    stat.Ppid,
+			User:    n.getUserName(process.Status.RealUid),
+			State:   stat.State,
+			CPU:     cpu,
+			RSS:     process.Status.VmRSS * 1024,
+			VSZ:     stat.Vsize,
+			Threads: stat.NumThreads,
+			Nice:    stat.Nice,
+			Command: command,
+		}
+
+		processLists = append(processLists, process)
+		processUsages = append(processUsages, processUsage)
+	}
 
-func (n *Node) getNetworkIO(device string) {
 	n.Lock()
-	defer n.Unlock()
+	n.LatestProcessLists = processLists
+	n.processUsages = processUsages
+	n.processCPUTimes = cpuTimes
+	n.processCPUTotal = cpuTotal
+	n.Unlock()
+}
 
-	if len(device) == 0 {
+// prefetch is notify the source of the paths read in this tick.
+func (n *Node) prefetch() {
+	if !n.CheckClientAlive() {
 		return
 	}
 
-	networkIO := n.NetworkIOs[device]
-	if len(networkIO) > 1 {
-		var rxBytes uint64
-		var txBytes uint64
-		var rxPackets uint64
-		var txPackets uint64
+	n.RLock()
+	mountPoints := n.mountPoints
+	n.RUnlock()
+
+	req := &PrefetchRequest{
+		Files: []string{
+			n.PathProcStat,
+			n.PathProcCpuinfo,
+			n.PathProcMeminfo,
+			n.PathProcUptime,
+			n.PathProcLoadavg,
+			n.PathProcMounts,
+			n.PathProcDiskStats,
+			n.PathProcVersion,
+			n.PathProcNetDev,
+			n.PathProcFibTrie,
+			n.PathProcRoute,
+			n.PathProcIfInet6,
+			filepath.Join(n.PathProcPressure, "*"),
+			filepath.Join(n.PathSysBlock, "dm-*", "dm", "name"),
+			filepath.Join(n.PathProc, "[0-9]*", "stat"),
+			filepath.Join(n.PathProc, "[0-9]*", "status"),
+			filepath.Join(n.PathProc, "[0-9]*", "cmdline"),
+		},
+		Dirs:   []string{n.PathProc},
+		StatFS: mountPoints,
+	}
 
-		preRXBytes := networkIO[len(networkIO)-2].RXBytes
-		preRXPackets := networkIO[len(networkIO)-2].RXPackets
-		preTXBytes := networkIO[len(networkIO)-2].TXBytes
-		preTXPackets := networkIO[len(networkIO)-2].TXPackets
+	err := n.getSource().Prefetch(req)
+	if err != nil {
+		log.Printf("Prefetch %s Error: %s", n.ServerName, err)
+	}
+}
 
-		rxBytes = networkIO[len(networkIO)-1].RXBytes - preRXBytes
-		rxPackets = networkIO[len(networkIO)-1].RXPackets - preRXPackets
-		txBytes = networkIO[len(networkIO)-1].TXBytes - preTXBytes
-		txPackets = networkIO[len(networkIO)-1].TXPackets - preTXPackets
+// StartMonitoring is the sampling loop of node.
+// All metrics are read once per tick, and published as Snapshot.
+func (n *Node) StartMonitoring() {
+	ticker := time.NewTicker(n.Interval)
+	defer ticker.Stop()
 
-		if len(n.NetworkRXBytes) == 0 {
-			n.NetworkRXBytes = append(n.NetworkRXBytes, 0)
-		} else {
-			n.NetworkRXBytes = append(n.NetworkRXBytes, uint64(rxBytes))
-		}
+	for range ticker.C {
+		n.publish(n.collect())
+	}
+}
 
-		if len(n.NetworkRXPackets) == 0 {
-			n.NetworkRXPackets = append(n.NetworkRXPackets, 0)
-		} else {
-			n.NetworkRXPackets = append(n.NetworkRXPackets, uint64(rxPackets))
+// updateDiskHistory is add the latest per second values of diskIOs to history.
+// If the counters are reset, 0 is added instead.
+func (n *Node) updateDiskHistory(diskIOs *Ring[DiskIO], history *DiskHistory) {
+	diskIO := diskIOs.Latest(2)
+	if len(diskIO) > 1 {
+		// per second. use the time between samples, because the interval of reading is not exact.
+		seconds := diskIO[1].Timestamp.Sub(diskIO[0].Timestamp).Seconds()
+		if seconds <= 0 {
+			return
 		}
 
-		if len(n.NetworkTXBytes) == 0 {
-			n.NetworkTXBytes = append(n.NetworkTXBytes, 0)
-		} else {
-			n.NetworkTXBytes = append(n.NetworkTXBytes, uint64(txBytes))
+		readBytes, okReadBytes := counterDelta(uint64(diskIO[1].ReadBytes), uint64(diskIO[0].ReadBytes))
+		writeBytes, okWriteBytes := counterDelta(uint64(diskIO[1].WriteBytes), uint64(diskIO[0].WriteBytes))
+		readIOs, okReadIOs := counterDelta(diskIO[1].ReadIOs, diskIO[0].ReadIOs)
+		writeIOs, okWriteIOs := counterDelta(diskIO[1].WriteIOs, diskIO[0].WriteIOs)
+		if !(okReadBytes && okWriteBytes && okReadIOs && okWriteIOs) {
+			n.recordCounterReset("disk", diskIO[1].Device)
+			readBytes, writeBytes, readIOs, writeIOs = 0, 0, 0, 0
 		}
 
-		if len(n.NetworkTXPackets) == 0 {
-			n.NetworkTXPackets = append(n.NetworkTXPackets, 0)
-		} else {
-			n.NetworkTXPackets = append(n.NetworkTXPackets, uint64(txPackets))
-		}
+		readIOBytes := int64(float64(readBytes) / seconds)
+		writeIOBytes := int64(float64(writeBytes) / seconds)
+		readIOPS := float64(readIOs) / seconds
+		writeIOPS := float64(writeIOs) / seconds
 
-		if len(n.NetworkRXBytes) > n.NetworkIOsLimit {
-			n.NetworkRXBytes = n.NetworkRXBytes[1:]
-		}
+		history.ReadBytes.Push(readIOBytes)
+		history.WriteBytes.Push(writeIOBytes)
+		history.ReadIOPS.Push(readIOPS)
+		history.WriteIOPS.Push(writeIOPS)
+	}
+}
 
-		if len(n.NetworkTXBytes) > n.NetworkIOsLimit {
-			n.NetworkTXBytes = n.NetworkTXBytes[1:]
-		}
+// getDiskHistory is return the history of the block device mounted as device.
+func (n *Node) getDiskHistory(device string) *DiskHistory {
+	n.RLock()
+	defer n.RUnlock()
 
-		if len(n.NetworkRXBytes) > n.NetworkIOsLimit {
-			n.NetworkRXBytes = n.NetworkRXBytes[1:]
+	name, ok := n.diskDevices[device]
+	if !ok {
+		// LVM logical volume is also mounted as /dev/<vg>/<lv>
+		name, ok = n.diskDevices[getLVMMapperPath(device)]
+	}
+	if !ok {
+		return nil
+	}
+
+	return n.DiskHistories[name]
+}
+
+// getNetworkIO is add the latest per second values of device to its history, and return the history.
+// If the counters are reset, 0 is added instead.
+func (n *Node) getNetworkIO(device string) (history *NetworkHistory) {
+	if len(device) == 0 {
+		return
+	}
+
+	n.Lock()
+	networkIOs, ok := n.NetworkIOs[device]
+	if !ok {
+		n.Unlock()
+		return
+	}
+
+	history, ok = n.NetworkHistories[device]
+	if !ok {
+		history = newNetworkHistory(n.History)
+		n.NetworkHistories[device] = history
+	}
+	n.Unlock()
+
+	networkIO := networkIOs.Latest(2)
+	if len(networkIO) > 1 {
+		// per second. use the time between samples, because the interval of reading is not exact.
+		seconds := networkIO[1].Timestamp.Sub(networkIO[0].Timestamp).Seconds()
+		if seconds <= 0 {
+			return
 		}
 
-		if len(n.NetworkRXPackets) > n.NetworkIOsLimit {
-			n.NetworkRXPackets = n.NetworkRXPackets[1:]
+		rxBytesDiff, okRXBytes := counterDelta(networkIO[1].RXBytes, networkIO[0].RXBytes)
+		rxPacketsDiff, okRXPackets := counterDelta(networkIO[1].RXPackets, networkIO[0].RXPackets)
+		txBytesDiff, okTXBytes := counterDelta(networkIO[1].TXBytes, networkIO[0].TXBytes)
+		txPacketsDiff, okTXPackets := counterDelta(networkIO[1].TXPackets, networkIO[0].TXPackets)
+		if !(okRXBytes && okRXPackets && okTXBytes && okTXPackets) {
+			n.recordCounterReset("network", device)
+			rxBytesDiff, rxPacketsDiff, txBytesDiff, txPacketsDiff = 0, 0, 0, 0
 		}
 
+		rxBytes := uint64(float64(rxBytesDiff) / seconds)
+		rxPackets := uint64(float64(rxPacketsDiff) / seconds)
+		txBytes := uint64(float64(txBytesDiff) / seconds)
+		txPackets := uint64(float64(txPacketsDiff) / seconds)
+
+		history.RXBytes.Push(rxBytes)
+		history.RXPackets.Push(rxPackets)
+		history.TXBytes.Push(txBytes)
+		history.TXPackets.Push(txPackets)
 	}
 
 	return
 }
+
+// recordCounterReset is record the reset of counter.
+func (n *Node) recordCounterReset(kind, device string) {
+	log.Printf("Counter reset %s: %s %s", n.ServerName, kind, device)
+
+	n.counterResets.Push(CounterReset{
+		Timestamp: time.Now(),
+		Kind:      kind,
+		Device:    device,
+	})
+}
+
+// checkUptime is invalidate all histories when uptime is decreased (the node was rebooted).
+func (n *Node) checkUptime(uptime *linux.Uptime) {
+	if uptime == nil {
+		return
+	}
+
+	n.Lock()
+	rebooted := uptime.Total < n.uptime
+	n.uptime = uptime.Total
+	n.Unlock()
+
+	if !rebooted {
+		return
+	}
+
+	n.recordCounterReset("uptime", "")
+	n.resetHistories()
+}
+
+// resetHistories is remove all samples and histories.
+func (n *Node) resetHistories() {
+	n.cpuUsage.Reset()
+
+	n.Lock()
+	defer n.Unlock()
+
+	n.DiskIOs = map[string]*Ring[DiskIO]{}
+	n.DiskHistories = map[string]*DiskHistory{}
+	n.NetworkIOs = map[string]*Ring[NetworkIO]{}
+	n.NetworkHistories = map[string]*NetworkHistory{}
+	n.pressures = map[string]*PressureHistory{}
+	n.processCPUTimes = map[uint64]uint64{}
+	n.processCPUTotal = 0
+}
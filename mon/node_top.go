@@ -7,7 +7,6 @@ package monitor
 import (
 	"fmt"
 	"sync"
-	"time"
 
 	mview "github.com/blacknon/mview"
 	"github.com/gdamore/tcell/v2"
@@ -15,13 +14,25 @@ import (
 
 var IOCount = 50
 
+// NetworkBits is show network rates in bits per second instead of bytes per second.
+var NetworkBits = false
+
+// MemoryDetail is show the fields of /proc/meminfo in top panel.
+var MemoryDetail = false
+
 type NodeTop struct {
 	Grid         *mview.Grid
 	CPUUsage     *TopCPUUsage
 	MemoryUsage  *TopMemoryUsage
+	MemoryDetail *TopMemoryDetail
 	Uptimes      *TopUptime
+	Pressure     *TopPressure
 	DiskUsage    *TopDiskInfomation
 	NetworkUsage *TopNetworkInfomation
+	Process      *TopProcess
+
+	// memoryDetail is whether MemoryDetail is in the current layout.
+	memoryDetail bool
 
 	sync.Mutex
 }
@@ -31,10 +42,12 @@ func (n *Node) CreateNodeTop() (err error) {
 	// | CPU()              | Memory()        | 2 line
 	// |                    | Swap()          |
 	// |                    | --------------- |
-	// |                    | Kernel Version  | 4 line
-	// |                    | Uptime          |
-	// |                    | Tasks           |
-	// |                    | LoadAvg         |
+	// |                    | Kernel Version  | PSI      | 4 line
+	// |                    | Uptime          |          |
+	// |                    | Tasks           |          |
+	// |                    | LoadAvg         |          |
+	// | ------------------------------------ |
+	// | MemoryDetail (toggle)                | 0(unlimited)
 	// | ------------------------------------ |
 	// | Disk        | Process                | 0(unlimited)
 	// | Network     |                        | 0(unlimited)
@@ -64,115 +77,125 @@ func (n *Node) CreateNodeTop() (err error) {
 	// create top panel
 	top.CPUUsage = n.CreateTopCPUUsage()
 	top.Uptimes = n.CreateTopUptime()
+	top.Pressure = n.CreateTopPressure()
 	top.MemoryUsage = n.CreateTopMemoryUsage()
+	top.MemoryDetail = n.CreateTopMemoryDetail()
 
 	top.DiskUsage = n.CreateTopDiskInfomation()
 
 	top.NetworkUsage = n.CreateTopNetworkInfomation()
-	topProcess := n.createBaseGridTopProcess()
+	top.Process = n.CreateTopProcess()
 
 	// Add top panel
-	// 1st, 2nd row
-	top.Grid.AddItem(top.CPUUsage, 0, 0, 2, 1, 0, 0, true)
-	top.Grid.AddItem(top.Uptimes, 0, 1, 1, 2, 0, 0, false)
-	top.Grid.AddItem(top.MemoryUsage, 1, 1, 1, 2, 0, 0, false)
+	top.setLayout()
 
-	// 3rd row
-	top.Grid.AddItem(createEmptyPrimitive(), 2, 0, 1, 3, 0, 0, true)
-
-	// 4th row
-	top.Grid.AddItem(top.DiskUsage, 3, 0, 1, 3, 0, 0, false)
-
-	// 5th row
-	top.Grid.AddItem(createEmptyPrimitive(), 4, 0, 1, 3, 0, 0, true)
+	// update each time Snapshot is published
+	n.AddSnapshotFunc(func(snapshot *Snapshot) {
+		top.Lock()
+		defer top.Unlock()
 
-	// 6th row
-	top.Grid.AddItem(top.NetworkUsage, 5, 0, 1, 3, 0, 0, false)
+		if !snapshot.Connected {
+			top.CPUUsage.Table.Clear()
+			top.CPUUsage = n.CreateTopCPUUsage()
 
-	// 7th row
-	top.Grid.AddItem(topProcess, 6, 0, 1, 3, 0, 0, false)
+			top.MemoryUsage.Table.Clear()
+			top.MemoryUsage = n.CreateTopMemoryUsage()
 
-	// go routine for update
-	go func() {
-		ticker := time.NewTicker(2 * time.Second)
-		defer ticker.Stop()
+			top.MemoryDetail.Table.Clear()
+			top.MemoryDetail = n.CreateTopMemoryDetail()
 
-		for range ticker.C {
-			if !n.CheckClientAlive() {
-				top.Grid.Clear()
+			top.Uptimes.Table.Clear()
+			top.Uptimes = n.CreateTopUptime()
 
-				top.CPUUsage.Table.Clear()
-				top.CPUUsage = n.CreateTopCPUUsage()
+			top.Pressure.Table.Clear()
+			top.Pressure = n.CreateTopPressure()
 
-				top.MemoryUsage.Table.Clear()
-				top.MemoryUsage = n.CreateTopMemoryUsage()
+			top.DiskUsage.Table.Clear()
+			top.DiskUsage = n.CreateTopDiskInfomation()
 
-				top.Uptimes.Table.Clear()
-				top.Uptimes = n.CreateTopUptime()
+			top.NetworkUsage.Table.Clear()
+			top.NetworkUsage = n.CreateTopNetworkInfomation()
 
-				top.DiskUsage.Table.Clear()
-				top.DiskUsage = n.CreateTopDiskInfomation()
+			top.Process.Table.Clear()
+			top.Process = n.CreateTopProcess()
 
-				top.NetworkUsage.Table.Clear()
-				top.NetworkUsage = n.CreateTopNetworkInfomation()
+			top.setLayout()
 
-				topProcess := n.createBaseGridTopProcess()
-
-				// Add top panel
-				// 1st, 2nd row
-				top.Grid.AddItem(top.CPUUsage, 0, 0, 2, 1, 0, 0, true)
-				top.Grid.AddItem(top.Uptimes, 0, 1, 1, 2, 0, 0, false)
-				top.Grid.AddItem(top.MemoryUsage, 1, 1, 1, 2, 0, 0, false)
+			return
+		}
 
-				// 3rd row
-				top.Grid.AddItem(createEmptyPrimitive(), 2, 0, 1, 3, 0, 0, true)
+		wg := sync.WaitGroup{}
+
+		wg.Add(8)
+		top.CPUUsage.Update(snapshot, &wg)
+		top.MemoryUsage.Update(snapshot, &wg)
+		top.MemoryDetail.Update(snapshot, &wg)
+		top.Uptimes.Update(snapshot, &wg)
+		top.Pressure.Update(snapshot, &wg)
+		top.DiskUsage.Update(snapshot, &wg)
+		top.NetworkUsage.Update(snapshot, &wg)
+		top.Process.Update(snapshot, &wg)
+
+		wg.Wait()
+
+		// Resize
+		if top.memoryDetail != MemoryDetail {
+			top.setLayout()
+		} else {
+			top.setRows()
+		}
+	})
 
-				// 4th row
-				top.Grid.AddItem(top.DiskUsage, 3, 0, 1, 3, 0, 0, false)
+	n.NodeTop = top
 
-				// 5th row
-				top.Grid.AddItem(createEmptyPrimitive(), 4, 0, 1, 3, 0, 0, true)
+	return
+}
 
-				// 6th row
-				top.Grid.AddItem(top.NetworkUsage, 5, 0, 1, 3, 0, 0, false)
+// setLayout is add the panels to grid. MemoryDetail is added only when it is enabled.
+func (top *NodeTop) setLayout() {
+	top.Grid.Clear()
+	top.memoryDetail = MemoryDetail
 
-				// 7th row
-				top.Grid.AddItem(topProcess, 6, 0, 1, 3, 0, 0, false)
+	// 1st, 2nd row
+	top.Grid.AddItem(top.CPUUsage, 0, 0, 2, 1, 0, 0, true)
+	top.Grid.AddItem(top.Uptimes, 0, 1, 1, 1, 0, 0, false)
+	top.Grid.AddItem(top.Pressure, 0, 2, 1, 1, 0, 0, false)
+	top.Grid.AddItem(top.MemoryUsage, 1, 1, 1, 2, 0, 0, false)
 
-				continue
-			} else {
-				wg := sync.WaitGroup{}
+	// 3rd row
+	top.Grid.AddItem(createEmptyPrimitive(), 2, 0, 1, 3, 0, 0, true)
 
-				wg.Add(5)
-				top.CPUUsage.Update(&wg)
-				top.MemoryUsage.Update(&wg)
-				top.Uptimes.Update(&wg)
-				top.DiskUsage.Update(&wg)
-				top.NetworkUsage.Update(&wg)
+	row := 3
+	if top.memoryDetail {
+		top.Grid.AddItem(top.MemoryDetail, row, 0, 1, 3, 0, 0, false)
+		top.Grid.AddItem(createEmptyPrimitive(), row+1, 0, 1, 3, 0, 0, true)
+		row += 2
+	}
 
-				wg.Wait()
-			}
+	// Disk
+	top.Grid.AddItem(top.DiskUsage, row, 0, 1, 3, 0, 0, false)
 
-			// Resize
-			height4Row := top.DiskUsage.GetRowCount()
-			height6Row := top.NetworkUsage.GetRowCount()
+	// empty
+	top.Grid.AddItem(createEmptyPrimitive(), row+1, 0, 1, 3, 0, 0, true)
 
-			top.Grid.SetRows(5, 2, 1, height4Row, 1, height6Row, -1)
-		}
-	}()
+	// Network
+	top.Grid.AddItem(top.NetworkUsage, row+2, 0, 1, 3, 0, 0, false)
 
-	n.NodeTop = top
+	// Process
+	top.Grid.AddItem(top.Process, row+3, 0, 1, 3, 0, 0, false)
 
-	return
+	top.setRows()
 }
 
-func (n *Node) createBaseGridTopProcess() mview.Primitive {
-	topProcess := mview.NewTextView()
-	topProcess.SetTextAlign(mview.AlignCenter)
-	topProcess.SetText("")
-	topProcess.SetBackgroundColor(mview.ColorUnset)
+// setRows is resize the rows of grid to the row count of panels.
+func (top *NodeTop) setRows() {
+	rows := []int{5, 2, 1}
+	if top.memoryDetail {
+		rows = append(rows, top.MemoryDetail.GetRowCount(), 1)
+	}
+	rows = append(rows, top.DiskUsage.GetRowCount(), 1, top.NetworkUsage.GetRowCount(), -1)
 
-	return topProcess
+	top.Grid.SetRows(rows...)
 }
 
 func createEmptyPrimitive() mview.Primitive {
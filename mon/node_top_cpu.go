@@ -35,10 +35,7 @@ func (n *Node) CreateTopCPUUsage() (result *TopCPUUsage) {
 	table.SetFixed(1, 0)
 
 	// Get and Set CPU Usage
-	usages, err := n.GetCPUCoreUsage()
-	if err != nil {
-		return
-	}
+	usages := n.GetSnapshot().CPUCoreUsages
 
 	// Headers
 	headers := getTopCPUHeader()
@@ -91,7 +88,7 @@ func (n *Node) CreateTopCPUUsage() (result *TopCPUUsage) {
 	return result
 }
 
-func (t *TopCPUUsage) Update(wg *sync.WaitGroup) {
+func (t *TopCPUUsage) Update(snapshot *Snapshot, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	if t.Node == nil {
@@ -99,8 +96,8 @@ func (t *TopCPUUsage) Update(wg *sync.WaitGroup) {
 	}
 
 	// Get and Set CPU Usage
-	usages, err := t.Node.GetCPUCoreUsage()
-	if err != nil {
+	usages := snapshot.CPUCoreUsages
+	if usages == nil {
 		for i := 1; i <= t.Table.GetRowCount(); i++ {
 			t.SetCell(i, 1, mview.NewTableCell(fmt.Sprintf("%6d", 0)))
 			t.SetCell(i, 1, mview.NewTableCell(fmt.Sprintf("[gray]%8.1f%%[none][%-20s]", float64(0), "-")))
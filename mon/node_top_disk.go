@@ -58,17 +58,14 @@ func (n *Node) CreateTopDiskInfomation() (result *TopDiskInfomation) {
 	return result
 }
 
-func (t *TopDiskInfomation) Update(wg *sync.WaitGroup) {
+func (t *TopDiskInfomation) Update(snapshot *Snapshot, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if t.Node == nil {
 		return
 	}
 
 	// Get and Set Memory Usage
-	diksinfo, err := t.Node.GetDiskUsage()
-	if err != nil {
-		return
-	}
+	diksinfo := snapshot.DiskUsages
 
 	for i, disk := range diksinfo {
 		row := i + 1
@@ -108,7 +105,7 @@ func (t *TopDiskInfomation) Update(wg *sync.WaitGroup) {
 		var diskReadIOCell *mview.TableCell
 		var readBytes []float64
 		if readIOBytesLength > 0 {
-			for i := 1; i < IOCount+1; i++ {
+			for i := 1; i < t.Node.getGraphCount(IOCount)+1; i++ {
 				var readByte float64
 				if i >= readIOBytesLength {
 					readByte = float64(0)
@@ -139,7 +136,7 @@ func (t *TopDiskInfomation) Update(wg *sync.WaitGroup) {
 		var diskWriteIOCell *mview.TableCell
 		var writeBytes []float64
 		if writeIOBytesLength > 0 {
-			for i := 1; i < IOCount+1; i++ {
+			for i := 1; i < t.Node.getGraphCount(IOCount)+1; i++ {
 				var writeByte float64
 				if i >= writeIOBytesLength {
 					writeByte = float64(0)
@@ -164,6 +161,22 @@ func (t *TopDiskInfomation) Update(wg *sync.WaitGroup) {
 		}
 		diskWriteIOCell.SetTextColor(tcell.NewRGBColor(0, 255, 255))
 		t.Table.SetCell(row, 6, diskWriteIOCell)
+
+		// Disk ReadIOPS, WriteIOPS(8, 9)
+		readIOPS := fmt.Sprintf("[gray]%s[none]", "-")
+		writeIOPS := fmt.Sprintf("[gray]%s[none]", "-")
+		if readIOBytesLength > 0 {
+			readIOPS = fmt.Sprintf("[gray]%9.1f[none]", disk.ReadIOPS)
+			writeIOPS = fmt.Sprintf("[gray]%9.1f[none]", disk.WriteIOPS)
+		}
+
+		diskReadIOPSCell := mview.NewTableCell(readIOPS)
+		diskReadIOPSCell.SetTextColor(tcell.NewRGBColor(0, 255, 255))
+		t.Table.SetCell(row, 7, diskReadIOPSCell)
+
+		diskWriteIOPSCell := mview.NewTableCell(writeIOPS)
+		diskWriteIOPSCell.SetTextColor(tcell.NewRGBColor(0, 255, 255))
+		t.Table.SetCell(row, 8, diskWriteIOPSCell)
 	}
 
 	sortColumn := t.GetSortClickedColumn()
@@ -178,7 +191,9 @@ func getTopDiskHeader() []string {
 		" Usage",
 		" Use",
 		" Total",
-		" ReadBytes",
-		" WriteBytes",
+		" ReadBytes/s",
+		" WriteBytes/s",
+		" ReadIOPS",
+		" WriteIOPS",
 	}
 }
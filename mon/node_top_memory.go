@@ -6,6 +6,9 @@ package monitor
 
 import (
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 	"sync"
 
 	mview "github.com/blacknon/mview"
@@ -56,27 +59,22 @@ func (n *Node) CreateTopMemoryUsage() (result *TopMemoryUsage) {
 	return result
 }
 
-func (t *TopMemoryUsage) Update(wg *sync.WaitGroup) {
+func (t *TopMemoryUsage) Update(snapshot *Snapshot, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if t.Node == nil {
 		return
 	}
 
 	// Get and Set Memory Usage
-	meminfo, err := t.Node.GetMemInfo()
-	if err != nil {
+	meminfo := snapshot.MemInfo
+	if meminfo == nil {
 		return
 	}
 
-	// Create Usage Size(memory)
-	memUsed := (meminfo.MemTotal - meminfo.MemFree - meminfo.Buffers - meminfo.Cached) * 1024
-	memTotal := (meminfo.MemTotal) * 1024
+	// Create Usage Size
+	memUsed, memTotal, swapUsed, swapTotal := snapshot.GetMemoryUsage()
 	humanizeMemUsed := humanize.Bytes(memUsed)
 	humanizeMemTotal := humanize.Bytes(memTotal)
-
-	// Create Usage Size(swap)
-	swapUsed := (meminfo.SwapTotal - meminfo.SwapFree) * 1024
-	swapTotal := (meminfo.SwapTotal) * 1024
 	humanizeSwapUsed := humanize.Bytes(swapUsed)
 	humanizeSwapTotal := humanize.Bytes(swapTotal)
 
@@ -94,6 +92,13 @@ func (t *TopMemoryUsage) Update(wg *sync.WaitGroup) {
 	)
 	t.Table.SetCell(0, 1, MemBar)
 
+	// legend of memory bar is shown with MemoryDetail
+	legend := ""
+	if MemoryDetail {
+		legend = " " + memoryBarLegend
+	}
+	t.Table.SetCell(0, 2, mview.NewTableCell(legend))
+
 	// Swap
 	SwapBar := mview.NewTableCell(
 		fmt.Sprintf(
@@ -106,46 +111,158 @@ func (t *TopMemoryUsage) Update(wg *sync.WaitGroup) {
 	t.Table.SetCell(1, 1, SwapBar)
 }
 
+// memoryBarLegend is the legend of memory bar.
+const memoryBarLegend = "[green]|[none]used [magenta]|[none]shared [cyan]|[none]slab [red]|[none]hugepages [yellow]|[none]dirty/writeback [blue]|[none]buffers/cache"
+
+// CreateMemoryBarGraph is create the bar of memory and swap.
+// The memory bar separate used, shared, slab, hugepages, dirty/writeback and buffers/cache.
+// used is MemTotal - MemAvailable without shared, unreclaimable slab and hugepages.
 func CreateMemoryBarGraph(length int, meminfo *linux.MemInfo) (memory, swap string) {
+	hugePages := meminfo.HugePages_Total * meminfo.Hugepagesize
+	dirty := meminfo.Dirty + meminfo.Writeback
+
+	// used by applications
+	used := subUint64(getMemUsed(meminfo), meminfo.Shmem, meminfo.SUnreclaim, hugePages)
+
+	// page cache. Cached include shared memory.
+	cache := subUint64(meminfo.Buffers+meminfo.Cached, meminfo.Shmem, dirty)
+
 	// memory
-	memUsedLength := int(float64(meminfo.MemTotal-meminfo.MemFree-meminfo.Buffers-meminfo.Cached) / float64(meminfo.MemTotal) * float64(length))
-	memBufLength := int(float64(meminfo.Buffers) / float64(meminfo.MemTotal) * float64(length))
-	memCacheLength := int(float64(meminfo.Cached) / float64(meminfo.MemTotal) * float64(length))
+	// NOTE: MemTotal(SwapTotal) is 0 in some container, or when swap is disabled. The bar is empty.
+	memory = createBarGraph(length, meminfo.MemTotal, []barSegment{
+		{"green", used},
+		{"magenta", meminfo.Shmem},
+		{"cyan", meminfo.Slab},
+		{"red", hugePages},
+		{"yellow", dirty},
+		{"blue", cache},
+	})
 
 	// swap
-	swapUsedLength := int(float64(meminfo.SwapTotal-meminfo.SwapFree) / float64(meminfo.SwapTotal) * float64(length))
+	swap = createBarGraph(length, meminfo.SwapTotal, []barSegment{
+		{"red", getSwapUsed(meminfo)},
+	})
 
-	// Create Mem Bar
-	memory = ""
-	for i := 0; i < memUsedLength; i++ {
-		// memUsedLength
-		memory += "[green]|"
+	return memory, swap
+}
+
+// barSegment is a part of bar graph.
+type barSegment struct {
+	color string
+	value uint64
+}
+
+// createBarGraph is create the bar graph of segments. segments over total is cut.
+func createBarGraph(length int, total uint64, segments []barSegment) (bar string) {
+	count := 0
+	if total > 0 {
+		for _, segment := range segments {
+			segmentLength := int(float64(segment.value) / float64(total) * float64(length))
+			for i := 0; i < segmentLength && count < length; i++ {
+				bar += fmt.Sprintf("[%s]|", segment.color)
+				count++
+			}
+		}
 	}
-	for i := memUsedLength; i < memUsedLength+memBufLength; i++ {
-		// memBufLength
-		memory += "[blue]|"
+
+	for ; count < length; count++ {
+		// none
+		bar += "[node] "
 	}
-	for i := memUsedLength + memBufLength; i < memUsedLength+memBufLength+memCacheLength; i++ {
-		// memCacheLength
-		memory += "[yellow]|"
+	bar += "[none]"
+
+	return bar
+}
+
+// subUint64 is subtract values from v. The result is not less than 0.
+func subUint64(v uint64, values ...uint64) uint64 {
+	for _, value := range values {
+		if value > v {
+			return 0
+		}
+		v -= value
 	}
-	for i := memUsedLength + memBufLength + memCacheLength; i < length; i++ {
-		// none
-		memory += "[node] "
+
+	return v
+}
+
+type TopMemoryDetail struct {
+	*mview.Table
+	Node *Node
+}
+
+// memoryDetailColumns is the number of fields in a row of TopMemoryDetail.
+const memoryDetailColumns = 4
+
+func (n *Node) CreateTopMemoryDetail() (result *TopMemoryDetail) {
+	// Create box
+	table := mview.NewTable()
+
+	// Set border options
+	table.SetBorder(false)
+
+	// Set background color(no color)
+	table.SetBackgroundColor(mview.ColorUnset)
+
+	// Set selected style
+	table.SetSelectedStyle(tcell.ColorBlack, tcell.NewRGBColor(0, 255, 255), tcell.AttrNone)
+
+	// Set header
+	for i := 0; i < memoryDetailColumns; i++ {
+		nameHeader := mview.NewTableCell(" MemInfo")
+		nameHeader.SetTextColor(tcell.ColorBlack)
+		nameHeader.SetBackgroundColor(tcell.ColorGreen)
+		table.SetCell(0, i*2, nameHeader)
+
+		valueHeader := mview.NewTableCell(" Size")
+		valueHeader.SetTextColor(tcell.ColorBlack)
+		valueHeader.SetBackgroundColor(tcell.ColorGreen)
+		table.SetCell(0, i*2+1, valueHeader)
 	}
-	memory += "[none]"
 
-	// Create Swap Bar
-	swap = ""
-	for i := 0; i < swapUsedLength; i++ {
-		// swapUsedLength
-		swap += "[red]|"
+	result = &TopMemoryDetail{
+		Table: table,
+		Node:  n,
 	}
-	for i := swapUsedLength; i < length; i++ {
-		// none
-		swap += "[node] "
+
+	return result
+}
+
+func (t *TopMemoryDetail) Update(snapshot *Snapshot, wg *sync.WaitGroup) {
+	defer wg.Done()
+	if t.Node == nil {
+		return
 	}
-	swap += "[none]"
 
-	return memory, swap
+	meminfo := snapshot.MemInfo
+	if meminfo == nil {
+		return
+	}
+
+	// the fields of /proc/meminfo. The name is the key in /proc/meminfo.
+	v := reflect.ValueOf(*meminfo)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := field.Tag.Get("field")
+		if name == "" {
+			name = field.Name
+		}
+
+		// HugePages_* are counts of pages, the others are kB.
+		value := v.Field(i).Uint()
+		text := humanize.Bytes(value * 1024)
+		if strings.HasPrefix(name, "HugePages_") {
+			text = strconv.FormatUint(value, 10)
+		}
+
+		row := 1 + i/memoryDetailColumns
+		column := (i % memoryDetailColumns) * 2
+
+		nameCell := mview.NewTableCell(fmt.Sprintf(" [gray]%s:[none]", name))
+		valueCell := mview.NewTableCell(fmt.Sprintf("%10s ", text))
+		valueCell.SetAlign(mview.AlignRight)
+
+		t.Table.SetCell(row, column, nameCell)
+		t.Table.SetCell(row, column+1, valueCell)
+	}
 }
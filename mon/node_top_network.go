@@ -62,17 +62,14 @@ func (n *Node) CreateTopNetworkInfomation() (result *TopNetworkInfomation) {
 	return result
 }
 
-func (t *TopNetworkInfomation) Update(wg *sync.WaitGroup) {
+func (t *TopNetworkInfomation) Update(snapshot *Snapshot, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if t.Node == nil {
 		return
 	}
 
 	// Get Network Infomation
-	networkUsages, err := t.Node.GetNetworkUsage()
-	if err != nil {
-		return
-	}
+	networkUsages := snapshot.NetworkUsages
 
 	// Set Network Infomation
 	for i, networkUsage := range networkUsages {
@@ -101,7 +98,7 @@ func (t *TopNetworkInfomation) Update(wg *sync.WaitGroup) {
 		var networkRXBytesCell *mview.TableCell
 		var rxBytes []float64
 		if rxBytesLength > 0 {
-			for i := 1; i < IOCount+1; i++ {
+			for i := 1; i < t.Node.getGraphCount(IOCount)+1; i++ {
 				var rxByte float64
 				if i >= rxBytesLength {
 					rxByte = float64(0)
@@ -110,7 +107,7 @@ func (t *TopNetworkInfomation) Update(wg *sync.WaitGroup) {
 				}
 				rxBytes = append(rxBytes, rxByte)
 			}
-			rxByte := humanize.Bytes(uint64(networkUsage.RXBytes[rxBytesLength-1]))
+			rxByte := formatNetworkRate(networkUsage.RXBytes[rxBytesLength-1])
 			maxBytes := scaleMaxValue(maxFloat64(rxBytes))
 			readGraph := Graph{
 				Data: rxBytes,
@@ -132,7 +129,7 @@ func (t *TopNetworkInfomation) Update(wg *sync.WaitGroup) {
 		var networkTXBytesCell *mview.TableCell
 		var txBytes []float64
 		if txBytesLength > 0 {
-			for i := 1; i < IOCount+1; i++ {
+			for i := 1; i < t.Node.getGraphCount(IOCount)+1; i++ {
 				var txByte float64
 				if i >= txBytesLength {
 					txByte = float64(0)
@@ -141,10 +138,10 @@ func (t *TopNetworkInfomation) Update(wg *sync.WaitGroup) {
 				}
 				txBytes = append(txBytes, txByte)
 			}
-			txByte := humanize.Bytes(uint64(networkUsage.TXBytes[txBytesLength-1]))
+			txByte := formatNetworkRate(networkUsage.TXBytes[txBytesLength-1])
 			maxBytes := scaleMaxValue(maxFloat64(txBytes))
 			readGraph := Graph{
-				Data: rxBytes,
+				Data: txBytes,
 				Max:  maxBytes,
 			}
 			brailleLine := strings.Join(readGraph.BrailleLine(), "")
@@ -163,7 +160,7 @@ func (t *TopNetworkInfomation) Update(wg *sync.WaitGroup) {
 		var networkRXPacketsCell *mview.TableCell
 		var rxPackets []float64
 		if rxPacketLength > 0 {
-			for i := 1; i < IOCount+1; i++ {
+			for i := 1; i < t.Node.getGraphCount(IOCount)+1; i++ {
 				var rxPacket float64
 				if i >= rxPacketLength {
 					rxPacket = float64(0)
@@ -194,7 +191,7 @@ func (t *TopNetworkInfomation) Update(wg *sync.WaitGroup) {
 		var networkTXPacketsCell *mview.TableCell
 		var txPackets []float64
 		if txPacketLength > 0 {
-			for i := 1; i < IOCount+1; i++ {
+			for i := 1; i < t.Node.getGraphCount(IOCount)+1; i++ {
 				var txPacket float64
 				if i >= txPacketLength {
 					txPacket = float64(0)
@@ -226,14 +223,35 @@ func (t *TopNetworkInfomation) Update(wg *sync.WaitGroup) {
 	t.Sort(sortColumn, isDescending)
 }
 
+// formatNetworkRate is format bytes per second. If NetworkBits is true, it is shown in bits per second.
+func formatNetworkRate(bytes uint64) string {
+	if NetworkBits {
+		return humanize.SIWithDigits(float64(bytes)*8, 1, "bps")
+	}
+
+	return humanize.Bytes(bytes)
+}
+
 func getTopNetworkHeader() []string {
+	if NetworkBits {
+		return []string{
+			" NetworkDevice",
+			" IPv4Address",
+			" IPv6Address",
+			" RXbps",
+			" TXbps",
+			" RXPackets/s",
+			" TXPackets/s",
+		}
+	}
+
 	return []string{
 		" NetworkDevice",
 		" IPv4Address",
 		" IPv6Address",
-		" RXBytes",
-		" TXBytes",
-		" RXPackets",
-		" TXPackets",
+		" RXBytes/s",
+		" TXBytes/s",
+		" RXPackets/s",
+		" TXPackets/s",
 	}
 }
@@ -0,0 +1,130 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	mview "github.com/blacknon/mview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// PressureCount is the number of points drawn in pressure graph.
+var PressureCount = 20
+
+type TopPressure struct {
+	*mview.Table
+	Node *Node
+}
+
+func (n *Node) CreateTopPressure() (result *TopPressure) {
+	// Create box
+	table := mview.NewTable()
+
+	// Set border options
+	table.SetBorder(false)
+
+	// Set background color(no color)
+	table.SetBackgroundColor(mview.ColorUnset)
+
+	// Set selected style
+	table.SetSelectedStyle(tcell.ColorBlack, tcell.NewRGBColor(0, 255, 255), tcell.AttrNone)
+
+	// Set header
+	headers := getTopPressureHeader()
+	for i, header := range headers {
+		headerCell := mview.NewTableCell(header)
+		headerCell.SetTextColor(tcell.ColorBlack)
+		headerCell.SetBackgroundColor(tcell.ColorGreen)
+		table.SetCell(0, i, headerCell)
+	}
+
+	result = &TopPressure{
+		Table: table,
+		Node:  n,
+	}
+
+	return result
+}
+
+func (t *TopPressure) Update(snapshot *Snapshot, wg *sync.WaitGroup) {
+	defer wg.Done()
+	if t.Node == nil {
+		return
+	}
+
+	// PSI is not supported
+	if len(snapshot.Pressures) == 0 {
+		for row := t.GetRowCount() - 1; row > 0; row-- {
+			t.RemoveRow(row)
+		}
+
+		unavailableCell := mview.NewTableCell(" [gray]unavailable[none]")
+		t.Table.SetCell(1, 0, unavailableCell)
+		return
+	}
+
+	for i, pressure := range snapshot.Pressures {
+		row := i + 1
+
+		// Resource
+		resourceCell := mview.NewTableCell(fmt.Sprintf(" %s", pressure.Resource))
+		resourceCell.SetTextColor(tcell.NewRGBColor(0, 255, 255))
+		t.Table.SetCell(row, 0, resourceCell)
+
+		// Some
+		someCell := mview.NewTableCell(fmt.Sprintf("%6.2f[gray]/[none]%6.2f", pressure.Some.Avg10, pressure.Some.Avg60))
+		t.Table.SetCell(row, 1, someCell)
+
+		// Full
+		full := fmt.Sprintf("%6.2f[gray]/[none]%6.2f", pressure.Full.Avg10, pressure.Full.Avg60)
+		if !pressure.HasFull {
+			full = fmt.Sprintf("%13s", "-")
+		}
+		fullCell := mview.NewTableCell(full)
+		t.Table.SetCell(row, 2, fullCell)
+
+		// History
+		historyCell := mview.NewTableCell(fmt.Sprintf("[gray]%s[none]", t.getBrailleLine(pressure.SomeHistory)))
+		t.Table.SetCell(row, 3, historyCell)
+	}
+
+	for row := t.GetRowCount() - 1; row > len(snapshot.Pressures); row-- {
+		t.RemoveRow(row)
+	}
+}
+
+// getBrailleLine is return braille line graph of avg10 history. The latest is left.
+func (t *TopPressure) getBrailleLine(history []float64) string {
+	count := t.Node.getGraphCount(PressureCount)
+
+	values := []float64{}
+	for i := 1; i <= count; i++ {
+		value := 0.0
+		if i <= len(history) {
+			value = history[len(history)-i]
+		}
+		values = append(values, value)
+	}
+
+	graph := Graph{
+		Data: values,
+		Max:  100,
+		Min:  0,
+	}
+
+	return strings.Join(graph.BrailleLine(), "")
+}
+
+func getTopPressureHeader() []string {
+	return []string{
+		" PSI",
+		" Some10/60",
+		" Full10/60",
+		" Some10(history)",
+	}
+}
@@ -3,3 +3,169 @@
 // that can be found in the LICENSE file.
 
 package monitor
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	mview "github.com/blacknon/mview"
+	"github.com/dustin/go-humanize"
+	"github.com/gdamore/tcell/v2"
+)
+
+type TopProcess struct {
+	*mview.Table
+	Node *Node
+}
+
+func (n *Node) CreateTopProcess() (result *TopProcess) {
+	// Create box
+	table := mview.NewTable()
+
+	// Set border options
+	table.SetBorder(false)
+
+	// Set background color(no color)
+	table.SetBackgroundColor(mview.ColorUnset)
+
+	// Set selected style
+	table.SetSelectedStyle(tcell.ColorBlack, tcell.NewRGBColor(0, 255, 255), tcell.AttrNone)
+
+	// Set fixed option
+	table.SetFixed(1, 0)
+
+	// Headers
+	headers := getTopProcessHeader()
+
+	// Set table header
+	for colIndex, header := range headers {
+		tableCell := mview.NewTableCell(header)
+		tableCell.SetTextColor(tcell.ColorBlack)
+		tableCell.SetBackgroundColor(tcell.ColorGreen)
+		tableCell.SetAlign(mview.AlignLeft)
+		tableCell.SetSelectable(false)
+		tableCell.SetIsHeader(true)
+
+		table.SetCell(0, colIndex, tableCell)
+	}
+
+	// Set sort func
+	table.SetSortFunc(func(column int, i, j []byte) bool {
+		switch column {
+		case 0, 3, 6, 7:
+			s1, _ := strconv.ParseFloat(strings.TrimSpace(string(mview.StripTags(i, true, false))), 64)
+			s2, _ := strconv.ParseFloat(strings.TrimSpace(string(mview.StripTags(j, true, false))), 64)
+
+			return s1 < s2
+		case 4, 5:
+			s1 := parseSize(string(mview.StripTags(i, true, false)))
+			s2 := parseSize(string(mview.StripTags(j, true, false)))
+
+			return s1 < s2
+		default:
+			return bytes.Compare(i, j) == -1
+		}
+	})
+
+	result = &TopProcess{
+		Table: table,
+		Node:  n,
+	}
+
+	return result
+}
+
+func (t *TopProcess) Update(snapshot *Snapshot, wg *sync.WaitGroup) {
+	defer wg.Done()
+	if t.Node == nil {
+		return
+	}
+
+	// Get Process List
+	processUsages := snapshot.Processes
+
+	for i, process := range processUsages {
+		row := i + 1
+
+		// PID
+		pidCell := mview.NewTableCell(fmt.Sprintf("%7d", process.PID))
+		pidCell.SetTextColor(tcell.NewRGBColor(0, 255, 255))
+		pidCell.SetAlign(mview.AlignRight)
+		t.SetCell(row, 0, pidCell)
+
+		// User
+		userCell := mview.NewTableCell(fmt.Sprintf("[gray]%-10s[none]", process.User))
+		userCell.SetTextColor(tcell.ColorWhite)
+		t.SetCell(row, 1, userCell)
+
+		// State
+		stateColor := "gray"
+		switch process.State {
+		case "R":
+			stateColor = "green"
+		case "D":
+			stateColor = "red"
+		case "Z":
+			stateColor = "yellow"
+		}
+		stateCell := mview.NewTableCell(fmt.Sprintf("[%s]%s[none]", stateColor, process.State))
+		stateCell.SetAlign(mview.AlignCenter)
+		t.SetCell(row, 2, stateCell)
+
+		// CPU%
+		cpuCell := mview.NewTableCell(fmt.Sprintf("[yellow]%6.1f[none]", process.CPU))
+		cpuCell.SetAlign(mview.AlignRight)
+		t.SetCell(row, 3, cpuCell)
+
+		// RSS
+		rssCell := mview.NewTableCell(fmt.Sprintf("[gray]%8s[none]", humanize.Bytes(process.RSS)))
+		rssCell.SetAlign(mview.AlignRight)
+		t.SetCell(row, 4, rssCell)
+
+		// VSZ
+		vszCell := mview.NewTableCell(fmt.Sprintf("[gray]%8s[none]", humanize.Bytes(process.VSZ)))
+		vszCell.SetAlign(mview.AlignRight)
+		t.SetCell(row, 5, vszCell)
+
+		// Threads
+		threadsCell := mview.NewTableCell(fmt.Sprintf("[gray]%4d[none]", process.Threads))
+		threadsCell.SetAlign(mview.AlignRight)
+		t.SetCell(row, 6, threadsCell)
+
+		// Nice
+		niceCell := mview.NewTableCell(fmt.Sprintf("[gray]%3d[none]", process.Nice))
+		niceCell.SetAlign(mview.AlignRight)
+		t.SetCell(row, 7, niceCell)
+
+		// Command
+		commandCell := mview.NewTableCell(process.Command)
+		commandCell.SetTextColor(tcell.ColorWhite)
+		t.SetCell(row, 8, commandCell)
+	}
+
+	// Remove exited process rows
+	for t.GetRowCount() > len(processUsages)+1 {
+		t.RemoveRow(t.GetRowCount() - 1)
+	}
+
+	sortColumn := t.GetSortClickedColumn()
+	isDescending := t.GetSortClickedDescending()
+	t.Sort(sortColumn, isDescending)
+}
+
+func getTopProcessHeader() []string {
+	return []string{
+		" PID",
+		" User",
+		" State",
+		" CPU%",
+		" RSS",
+		" VSZ",
+		" Threads",
+		" Nice",
+		" Command",
+	}
+}
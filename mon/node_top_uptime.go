@@ -6,6 +6,7 @@ package monitor
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	mview "github.com/blacknon/mview"
@@ -70,15 +71,15 @@ func (n *Node) CreateTopUptime() (result *TopUptime) {
 	return result
 }
 
-func (t *TopUptime) Update(wg *sync.WaitGroup) {
+func (t *TopUptime) Update(snapshot *Snapshot, wg *sync.WaitGroup) {
 	defer wg.Done()
 	if t.Node == nil {
 		return
 	}
 
 	// Get and Set Kernel Version
-	kernel, err := t.Node.GetKernelVersion()
-	if err != nil {
+	kernel := snapshot.KernelVersion
+	if kernel == "" {
 		return
 	}
 	kernelCell := mview.NewTableCell(fmt.Sprintf(" %s", kernel))
@@ -86,18 +87,26 @@ func (t *TopUptime) Update(wg *sync.WaitGroup) {
 	t.Table.SetCell(0, 1, kernelCell)
 
 	// Get and Set Uptime
-	uptime, err := t.Node.GetUptime()
-	if err != nil {
+	uptime := snapshot.Uptime
+	if uptime == nil {
 		return
 	}
 	uptimeTotal := uptime.GetTotalDuration()
-	uptimeCell := mview.NewTableCell(fmt.Sprintf(" %s", uptimeFormatDuration(uptimeTotal)))
+	uptimeText := fmt.Sprintf(" %s", uptimeFormatDuration(uptimeTotal))
+
+	// show the latest counter reset. the rate of the sample is dropped.
+	if len(snapshot.CounterResets) > 0 {
+		reset := snapshot.CounterResets[len(snapshot.CounterResets)-1]
+		uptimeText += fmt.Sprintf("  [yellow]reset:[none] %s %s", strings.TrimSpace(reset.Kind+" "+reset.Device), reset.Timestamp.Format("15:04:05"))
+	}
+
+	uptimeCell := mview.NewTableCell(uptimeText)
 	uptimeCell.SetTextColor(tcell.NewRGBColor(0, 255, 255))
 	t.Table.SetCell(1, 1, uptimeCell)
 
 	// Get and Set Tasks
-	tasks, err := t.Node.GetTaskCounts()
-	if err != nil {
+	tasks := snapshot.Tasks
+	if snapshot.Processes == nil {
 		return
 	}
 	tasksCell := mview.NewTableCell(fmt.Sprintf(" [gray]Tasks:[none] %d", tasks))
@@ -105,8 +114,8 @@ func (t *TopUptime) Update(wg *sync.WaitGroup) {
 	t.Table.SetCell(2, 1, tasksCell)
 
 	// Get and Set LoadAvg
-	loadavg, err := t.Node.GetLoadAvg()
-	if err != nil {
+	loadavg := snapshot.LoadAvg
+	if loadavg == nil {
 		return
 	}
 	loadAvg1min := loadavg.Last1Min
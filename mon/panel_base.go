@@ -21,7 +21,7 @@ func (m *Monitor) createBasePanel() (baseGrid *mview.Grid) {
 	m.table = m.createBaseGridTable()
 
 	// 定期的なデータの更新を行わせる
-	go m.updateBaseGridTableRows()
+	m.startBaseGridTableUpdate()
 	go m.reconnectServer()
 
 	// create base grid
@@ -58,6 +58,19 @@ func (m *Monitor) createBasePanel() (baseGrid *mview.Grid) {
 			// baseGrid Clear
 			m.reDrawBasePanel()
 
+			// draw
+			m.View.Draw()
+
+		case tcell.KeyCtrlE:
+			// toggle /proc/meminfo detail in top panel
+			MemoryDetail = !MemoryDetail
+
+			for _, node := range m.Nodes {
+				node.NodeTop.Lock()
+				node.NodeTop.setLayout()
+				node.NodeTop.Unlock()
+			}
+
 			// draw
 			m.View.Draw()
 		}
@@ -136,7 +149,7 @@ func (m *Monitor) createFooter() mview.Primitive {
 	footer := mview.NewTextView()
 
 	footer.SetDynamicColors(true)
-	footer.SetText("Ctrl-X[black:#00ffff]ToggleTopPanel[white]  ")
+	footer.SetText("Ctrl-X[black:#00ffff]ToggleTopPanel[white]  Ctrl-E[black:#00ffff]ToggleMemInfo[white]  ")
 	footer.SetBackgroundColor(mview.ColorUnset)
 	footer.SetTextAlign(mview.AlignLeft)
 
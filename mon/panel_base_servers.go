@@ -7,8 +7,7 @@ package monitor
 import (
 	"bytes"
 	"fmt"
-	"sync"
-	"time"
+	"strings"
 	"unsafe"
 
 	mview "github.com/blacknon/mview"
@@ -66,7 +65,7 @@ func (m *Monitor) createBaseGridTable() (table *mview.Table) {
 		})
 
 	// Headers
-	headers := getServerHeader()
+	headers := m.getServerHeader()
 
 	// Rows
 	rows := m.createBaseGridTableRows()
@@ -141,7 +140,7 @@ func (m *Monitor) createBaseGridTableRows() (rows [][]string) {
 		// ServerName
 		row = append(row, node.ServerName)
 
-		for i := 0; i <= 13; i++ {
+		for i := 1; i < len(m.getServerHeader()); i++ {
 			row = append(row, "")
 		}
 
@@ -151,113 +150,82 @@ func (m *Monitor) createBaseGridTableRows() (rows [][]string) {
 	return
 }
 
-type UpdateRows struct {
-	data map[string][]*mview.TableCell
-	sync.RWMutex
-}
-
-func (m *Monitor) updateBaseGridTableRows() {
-	// Get table rows count
-	count := m.table.GetRowCount()
-
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		nodeRows := &UpdateRows{data: map[string][]*mview.TableCell{}}
-
-		// Get update rows
-		var wg1 sync.WaitGroup
-		for i := 0; i < count-1; i++ {
-			row := i + 1
-			server := m.table.GetCell(row, 0).GetText()
-
-			node := m.GetNode(server)
-			if node == nil {
-				continue
-			}
+// startBaseGridTableUpdate is set the function to update the row of node each time Snapshot is published.
+func (m *Monitor) startBaseGridTableUpdate() {
+	for _, node := range m.Nodes {
+		server := node.ServerName
 
-			wg1.Add(1)
-			go func() {
-				defer wg1.Done()
+		node.AddSnapshotFunc(func(snapshot *Snapshot) {
+			cells := m.updateBaseGridTableAtNode(snapshot)
 
-				rows := m.updateBaseGridTableAtNode(node)
+			m.View.QueueUpdateDraw(func() {
+				m.setBaseGridTableRow(server, cells)
+			})
+		})
+	}
+}
 
-				nodeRows.Lock()
-				nodeRows.data[server] = rows
-				nodeRows.Unlock()
-			}()
+// setBaseGridTableRow is set cells to the row of server. It is called in the view goroutine.
+func (m *Monitor) setBaseGridTableRow(server string, cells []*mview.TableCell) {
+	for row := 1; row < m.table.GetRowCount(); row++ {
+		if strings.TrimSpace(m.table.GetCell(row, 0).GetText()) != server {
+			continue
 		}
-		wg1.Wait()
-
-		// Update rows
-		var wg2 sync.WaitGroup
-		for i := 0; i < count-1; i++ {
-			row := i + 1
-			server := m.table.GetCell(row, 0).GetText()
-
-			if _, ok := nodeRows.data[server]; !ok {
-				continue
-			}
-
-			for col, cell := range nodeRows.data[server] {
-				wg2.Add(1)
-				m.View.QueueUpdate(
-					func() {
-						defer wg2.Done()
-						m.table.SetCell(row, col+1, cell)
-					})
-			}
 
+		for col, cell := range cells {
+			m.table.SetCell(row, col+1, cell)
 		}
-		wg2.Wait()
-
-		sortColumn := m.table.GetSortClickedColumn()
-		isDescending := m.table.GetSortClickedDescending()
-		m.table.Sort(sortColumn, isDescending)
-
-		m.View.Draw()
+		break
 	}
+
+	sortColumn := m.table.GetSortClickedColumn()
+	isDescending := m.table.GetSortClickedDescending()
+	m.table.Sort(sortColumn, isDescending)
 }
 
-func (m *Monitor) updateBaseGridTableAtNode(node *Node) (result []*mview.TableCell) {
-	// if node is not connect, connect node.
-	isConnect := node.CheckClientAlive()
+func (m *Monitor) updateBaseGridTableAtNode(snapshot *Snapshot) (result []*mview.TableCell) {
+	isConnect := snapshot.Connected
 
 	// 2nd(1)
 	connectCell := m.getBaseGridTableDataIsConnect(isConnect)
 	result = append(result, connectCell)
 
 	// 3rd(2)
-	uptimeCell := m.getBaseGridTableDataUptime(isConnect, node)
+	uptimeCell := m.getBaseGridTableDataUptime(isConnect, snapshot)
 	result = append(result, uptimeCell)
 
 	// 4th(3)
-	cpuCoreCell := m.getBaseGridTableDataCPUCore(isConnect, node)
+	cpuCoreCell := m.getBaseGridTableDataCPUCore(isConnect, snapshot)
 	result = append(result, cpuCoreCell)
 
 	// 5th(4)
-	// cpuUsageCell := m.getBaseGridTableDataCPUUsageWithSparkline(isConnect, node)
-	cpuUsageCell := m.getBaseGridTableDataCPUUsageWithBrailleLine(isConnect, node)
+	// cpuUsageCell := m.getBaseGridTableDataCPUUsageWithSparkline(isConnect, snapshot)
+	cpuUsageCell := m.getBaseGridTableDataCPUUsageWithBrailleLine(isConnect, snapshot)
 	result = append(result, cpuUsageCell)
 
 	// 6th, 7th, 8th, 9th(5, 6, 7, 8)
-	memUseCell, memTotalCell, swapUseCell, swapTotalCell := m.getBaseGridTableDataMemUsage(isConnect, node)
+	memUseCell, memTotalCell, swapUseCell, swapTotalCell := m.getBaseGridTableDataMemUsage(isConnect, snapshot)
 	result = append(result, memUseCell, memTotalCell, swapUseCell, swapTotalCell)
 
 	// 10th(9)
-	tasksCell := m.getBaseGridTableDataTasks(isConnect, node)
+	tasksCell := m.getBaseGridTableDataTasks(isConnect, snapshot)
 	result = append(result, tasksCell)
 
 	// 11th, 12th, 13th
-	loadAvg15minCell, loadAvg5minCell, loadAvg1minCell := m.getBaseGridTableDataLoadAvg(isConnect, node)
+	loadAvg15minCell, loadAvg5minCell, loadAvg1minCell := m.getBaseGridTableDataLoadAvg(isConnect, snapshot)
 	result = append(result, loadAvg15minCell, loadAvg5minCell, loadAvg1minCell)
 
+	// PSI(optional)
+	if m.Option.Pressure {
+		pressureCells := m.getBaseGridTableDataPressure(isConnect, snapshot)
+		result = append(result, pressureCells...)
+	}
+
 	return
 }
 
-func getServerHeader() []string {
-	return []string{
+func (m *Monitor) getServerHeader() (headers []string) {
+	headers = []string{
 		" Server",
 		" Connect",
 		" Uptime",
@@ -272,4 +240,18 @@ func getServerHeader() []string {
 		" LoadAvg5min",
 		" LoadAvg1min",
 	}
+
+	// PSI(optional). some and full of avg10/avg60.
+	if m.Option.Pressure {
+		headers = append(headers,
+			" CPUSome",
+			" CPUFull",
+			" MemSome",
+			" MemFull",
+			" IOSome",
+			" IOFull",
+		)
+	}
+
+	return
 }
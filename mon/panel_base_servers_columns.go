@@ -25,10 +25,10 @@ func (m *Monitor) getBaseGridTableDataIsConnect(isConnect bool) (connectCell *mv
 	return
 }
 
-func (m *Monitor) getBaseGridTableDataUptime(isConnect bool, node *Node) (uptimeCell *mview.TableCell) {
+func (m *Monitor) getBaseGridTableDataUptime(isConnect bool, snapshot *Snapshot) (uptimeCell *mview.TableCell) {
 	if isConnect {
-		uptime, err := node.GetUptime()
-		if err != nil || uptime == nil {
+		uptime := snapshot.Uptime
+		if uptime == nil {
 			uptimeCell = mview.NewTableCell("-")
 			uptimeCell.Align = mview.AlignCenter
 		} else {
@@ -44,10 +44,10 @@ func (m *Monitor) getBaseGridTableDataUptime(isConnect bool, node *Node) (uptime
 	return
 }
 
-func (m *Monitor) getBaseGridTableDataCPUCore(isConnect bool, node *Node) (cpuCoreCell *mview.TableCell) {
+func (m *Monitor) getBaseGridTableDataCPUCore(isConnect bool, snapshot *Snapshot) (cpuCoreCell *mview.TableCell) {
 	if isConnect {
-		cpuCore, err := node.GetCPUCore()
-		if err != nil {
+		cpuCore := snapshot.CPUCore
+		if cpuCore == 0 {
 			cpuCoreCell = mview.NewTableCell("-")
 			cpuCoreCell.Align = mview.AlignCenter
 		} else {
@@ -64,11 +64,11 @@ func (m *Monitor) getBaseGridTableDataCPUCore(isConnect bool, node *Node) (cpuCo
 	return
 }
 
-func (m *Monitor) getBaseGridTableDataCPUUsage(isConnect bool, node *Node) (cpuUsageCell *mview.TableCell) {
+func (m *Monitor) getBaseGridTableDataCPUUsage(isConnect bool, snapshot *Snapshot) (cpuUsageCell *mview.TableCell) {
 	if isConnect {
-		cpuUsage, err := node.GetCPUUsage()
+		cpuUsage := snapshot.CPUUsage
 
-		if err != nil {
+		if len(snapshot.CPUUsages) == 0 {
 			cpuUsageCell = mview.NewTableCell("-")
 			cpuUsageCell.Align = mview.AlignCenter
 		} else {
@@ -83,11 +83,12 @@ func (m *Monitor) getBaseGridTableDataCPUUsage(isConnect bool, node *Node) (cpuU
 	return
 }
 
-func (m *Monitor) getBaseGridTableDataCPUUsageWithSparkline(isConnect bool, node *Node) (cpuUsageCell *mview.TableCell) {
+func (m *Monitor) getBaseGridTableDataCPUUsageWithSparkline(isConnect bool, snapshot *Snapshot) (cpuUsageCell *mview.TableCell) {
 	if isConnect {
-		cpuUsage, sparkline, err := node.GetCPUUsageWithSparkline()
+		cpuUsage := snapshot.CPUUsage
+		sparkline := snapshot.GetCPUSparkline(10)
 
-		if err != nil {
+		if len(snapshot.CPUUsages) == 0 {
 			cpuUsageCell = mview.NewTableCell("-")
 			cpuUsageCell.Align = mview.AlignCenter
 		} else {
@@ -102,11 +103,12 @@ func (m *Monitor) getBaseGridTableDataCPUUsageWithSparkline(isConnect bool, node
 	return
 }
 
-func (m *Monitor) getBaseGridTableDataCPUUsageWithBrailleLine(isConnect bool, node *Node) (cpuUsageCell *mview.TableCell) {
+func (m *Monitor) getBaseGridTableDataCPUUsageWithBrailleLine(isConnect bool, snapshot *Snapshot) (cpuUsageCell *mview.TableCell) {
 	if isConnect {
-		cpuUsage, sparkline, err := node.GetCPUUsageWithBrailleLine()
+		cpuUsage := snapshot.CPUUsage
+		sparkline := snapshot.GetCPUBrailleLine(21)
 
-		if err != nil {
+		if len(snapshot.CPUUsages) == 0 {
 			cpuUsageCell = mview.NewTableCell("-")
 			cpuUsageCell.Align = mview.AlignCenter
 		} else {
@@ -121,11 +123,11 @@ func (m *Monitor) getBaseGridTableDataCPUUsageWithBrailleLine(isConnect bool, no
 	return
 }
 
-func (m *Monitor) getBaseGridTableDataMemUsage(isConnect bool, node *Node) (memUseCell, memTotalCell, swapUseCell, swapTotalCell *mview.TableCell) {
+func (m *Monitor) getBaseGridTableDataMemUsage(isConnect bool, snapshot *Snapshot) (memUseCell, memTotalCell, swapUseCell, swapTotalCell *mview.TableCell) {
 	if isConnect {
-		memUsed, memTotal, swapUsed, swapTotal, err := node.GetMemoryUsage()
+		memUsed, memTotal, swapUsed, swapTotal := snapshot.GetMemoryUsage()
 
-		if err != nil {
+		if snapshot.MemInfo == nil {
 			memUseCell = mview.NewTableCell("-")
 			memUseCell.Align = mview.AlignCenter
 
@@ -172,11 +174,11 @@ func (m *Monitor) getBaseGridTableDataMemUsage(isConnect bool, node *Node) (memU
 	return
 }
 
-func (m *Monitor) getBaseGridTableDataTasks(isConnect bool, node *Node) (tasksCell *mview.TableCell) {
+func (m *Monitor) getBaseGridTableDataTasks(isConnect bool, snapshot *Snapshot) (tasksCell *mview.TableCell) {
 	if isConnect {
-		tasks, err := node.GetTaskCounts()
+		tasks := snapshot.Tasks
 
-		if err != nil {
+		if snapshot.Processes == nil {
 			tasksCell = mview.NewTableCell("-")
 			tasksCell.Align = mview.AlignCenter
 		} else {
@@ -191,11 +193,11 @@ func (m *Monitor) getBaseGridTableDataTasks(isConnect bool, node *Node) (tasksCe
 	return
 }
 
-func (m *Monitor) getBaseGridTableDataLoadAvg(isConnect bool, node *Node) (loadAvg15minCell, loadAvg5minCell, loadAvg1minCell *mview.TableCell) {
+func (m *Monitor) getBaseGridTableDataLoadAvg(isConnect bool, snapshot *Snapshot) (loadAvg15minCell, loadAvg5minCell, loadAvg1minCell *mview.TableCell) {
 	if isConnect {
-		loadAvg, err := node.GetLoadAvg()
+		loadAvg := snapshot.LoadAvg
 
-		if err != nil || loadAvg == nil {
+		if loadAvg == nil {
 			loadAvg15minCell = mview.NewTableCell("-")
 			loadAvg15minCell.Align = mview.AlignCenter
 
@@ -228,3 +230,38 @@ func (m *Monitor) getBaseGridTableDataLoadAvg(isConnect bool, node *Node) (loadA
 
 	return
 }
+
+// getBaseGridTableDataPressure is return the cells of some and full avg10/avg60 of cpu, memory and io.
+func (m *Monitor) getBaseGridTableDataPressure(isConnect bool, snapshot *Snapshot) (pressureCells []*mview.TableCell) {
+	pressures := map[string]*PressureUsage{}
+	if isConnect {
+		for _, pressure := range snapshot.Pressures {
+			pressures[pressure.Resource] = pressure
+		}
+	}
+
+	for _, resource := range PressureResources {
+		pressure, ok := pressures[resource]
+
+		var someCell, fullCell *mview.TableCell
+		if ok {
+			someCell = mview.NewTableCell(fmt.Sprintf("%6.2f[gray]/[none]%6.2f", pressure.Some.Avg10, pressure.Some.Avg60))
+			someCell.Align = mview.AlignRight
+		} else {
+			someCell = mview.NewTableCell("-")
+			someCell.Align = mview.AlignCenter
+		}
+
+		if ok && pressure.HasFull {
+			fullCell = mview.NewTableCell(fmt.Sprintf("%6.2f[gray]/[none]%6.2f", pressure.Full.Avg10, pressure.Full.Avg60))
+			fullCell.Align = mview.AlignRight
+		} else {
+			fullCell = mview.NewTableCell("-")
+			fullCell.Align = mview.AlignCenter
+		}
+
+		pressureCells = append(pressureCells, someCell, fullCell)
+	}
+
+	return
+}
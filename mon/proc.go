@@ -0,0 +1,789 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/c9s/goprocinfo/linux"
+)
+
+// NOTE:
+//   The files are read through ProcSource, and parsed in this file.
+//   The parse logic is based on github.com/c9s/goprocinfo and github.com/blacknon/go-sshproc.
+
+type IPv4 struct {
+	Interface string
+	IPAddress net.IP
+	Netmask   net.IPMask
+}
+
+type IPv6 struct {
+	Interface string
+	IPAddress net.IP
+	Prefix    string
+}
+
+// PressureStat is a line (some or full) of /proc/pressure/<resource>.
+// Avg10, Avg60 and Avg300 are percentage, Total is microseconds.
+type PressureStat struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+	Total  uint64
+}
+
+// Pressure is Pressure Stall Information of a resource (cpu, memory, io).
+// HasFull is false when the file has not `full` line (cpu before Linux 5.13).
+type Pressure struct {
+	Some    PressureStat
+	Full    PressureStat
+	HasFull bool
+}
+
+var (
+	cpuinfoRegExp     = regexp.MustCompile(`([^:]*?)\s*:\s*(.*)$`)
+	processStatRegExp = regexp.MustCompile(`^(\d+)( \(.*\) )(.*)$`)
+	pidRegExp         = regexp.MustCompile(`^\d+$`)
+)
+
+func readString(src ProcSource, path string) (data string, err error) {
+	b, err := src.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	data = string(b)
+	return
+}
+
+func readStat(src ProcSource, path string) (stat *linux.Stat, err error) {
+	data, err := readString(src, path)
+	if err != nil {
+		return
+	}
+
+	stat = &linux.Stat{}
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch {
+		case fields[0] == "cpu":
+			stat.CPUStatAll = createCPUStat(fields)
+		case strings.HasPrefix(fields[0], "cpu"):
+			stat.CPUStats = append(stat.CPUStats, createCPUStat(fields))
+		case fields[0] == "intr":
+			stat.Interrupts, _ = strconv.ParseUint(fields[1], 10, 64)
+		case fields[0] == "ctxt":
+			stat.ContextSwitches, _ = strconv.ParseUint(fields[1], 10, 64)
+		case fields[0] == "btime":
+			seconds, _ := strconv.ParseInt(fields[1], 10, 64)
+			stat.BootTime = time.Unix(seconds, 0)
+		case fields[0] == "processes":
+			stat.Processes, _ = strconv.ParseUint(fields[1], 10, 64)
+		case fields[0] == "procs_running":
+			stat.ProcsRunning, _ = strconv.ParseUint(fields[1], 10, 64)
+		case fields[0] == "procs_blocked":
+			stat.ProcsBlocked, _ = strconv.ParseUint(fields[1], 10, 64)
+		}
+	}
+
+	return
+}
+
+func createCPUStat(fields []string) (s linux.CPUStat) {
+	s.Id = fields[0]
+
+	for i := 1; i < len(fields); i++ {
+		v, _ := strconv.ParseUint(fields[i], 10, 64)
+		switch i {
+		case 1:
+			s.User = v
+		case 2:
+			s.Nice = v
+		case 3:
+			s.System = v
+		case 4:
+			s.Idle = v
+		case 5:
+			s.IOWait = v
+		case 6:
+			s.IRQ = v
+		case 7:
+			s.SoftIRQ = v
+		case 8:
+			s.Steal = v
+		case 9:
+			s.Guest = v
+		case 10:
+			s.GuestNice = v
+		}
+	}
+
+	return
+}
+
+func readCPUInfo(src ProcSource, path string) (cpuinfo *linux.CPUInfo, err error) {
+	data, err := readString(src, path)
+	if err != nil {
+		return
+	}
+
+	cpuinfo = &linux.CPUInfo{}
+	var processor *linux.Processor
+	for _, line := range strings.Split(data, "\n") {
+		submatches := cpuinfoRegExp.FindStringSubmatch(line)
+		if submatches == nil {
+			continue
+		}
+
+		key := submatches[1]
+		value := submatches[2]
+
+		if key == "processor" {
+			cpuinfo.Processors = append(cpuinfo.Processors, linux.Processor{CoreId: -1, PhysicalId: -1})
+			processor = &cpuinfo.Processors[len(cpuinfo.Processors)-1]
+			processor.Id, _ = strconv.ParseInt(value, 10, 64)
+			continue
+		}
+
+		if processor == nil {
+			continue
+		}
+
+		switch key {
+		case "vendor_id":
+			processor.VendorId = value
+		case "model":
+			processor.Model, _ = strconv.ParseInt(value, 10, 64)
+		case "model name":
+			processor.ModelName = value
+		case "cpu cores":
+			processor.Cores, _ = strconv.ParseInt(value, 10, 64)
+		case "cpu MHz":
+			processor.MHz, _ = strconv.ParseFloat(value, 64)
+		case "physical id":
+			processor.PhysicalId, _ = strconv.ParseInt(value, 10, 64)
+		case "core id":
+			processor.CoreId, _ = strconv.ParseInt(value, 10, 64)
+		}
+	}
+
+	return
+}
+
+func readMemInfo(src ProcSource, path string) (meminfo *linux.MemInfo, err error) {
+	data, err := readString(src, path)
+	if err != nil {
+		return
+	}
+
+	statMap := parseKeyValue(data)
+
+	meminfo = &linux.MemInfo{
+		MemTotal:          statMap["MemTotal"],
+		MemFree:           statMap["MemFree"],
+		MemAvailable:      statMap["MemAvailable"],
+		Buffers:           statMap["Buffers"],
+		Cached:            statMap["Cached"],
+		SwapCached:        statMap["SwapCached"],
+		Active:            statMap["Active"],
+		Inactive:          statMap["Inactive"],
+		ActiveAnon:        statMap["Active(anon)"],
+		InactiveAnon:      statMap["Inactive(anon)"],
+		ActiveFile:        statMap["Active(file)"],
+		InactiveFile:      statMap["Inactive(file)"],
+		Unevictable:       statMap["Unevictable"],
+		Mlocked:           statMap["Mlocked"],
+		SwapTotal:         statMap["SwapTotal"],
+		SwapFree:          statMap["SwapFree"],
+		Dirty:             statMap["Dirty"],
+		Writeback:         statMap["Writeback"],
+		AnonPages:         statMap["AnonPages"],
+		Mapped:            statMap["Mapped"],
+		Shmem:             statMap["Shmem"],
+		Slab:              statMap["Slab"],
+		SReclaimable:      statMap["SReclaimable"],
+		SUnreclaim:        statMap["SUnreclaim"],
+		KernelStack:       statMap["KernelStack"],
+		PageTables:        statMap["PageTables"],
+		NFS_Unstable:      statMap["NFS_Unstable"],
+		Bounce:            statMap["Bounce"],
+		WritebackTmp:      statMap["WritebackTmp"],
+		CommitLimit:       statMap["CommitLimit"],
+		Committed_AS:      statMap["Committed_AS"],
+		VmallocTotal:      statMap["VmallocTotal"],
+		VmallocUsed:       statMap["VmallocUsed"],
+		VmallocChunk:      statMap["VmallocChunk"],
+		HardwareCorrupted: statMap["HardwareCorrupted"],
+		AnonHugePages:     statMap["AnonHugePages"],
+		HugePages_Total:   statMap["HugePages_Total"],
+		HugePages_Free:    statMap["HugePages_Free"],
+		HugePages_Rsvd:    statMap["HugePages_Rsvd"],
+		HugePages_Surp:    statMap["HugePages_Surp"],
+		Hugepagesize:      statMap["Hugepagesize"],
+		DirectMap4k:       statMap["DirectMap4k"],
+		DirectMap2M:       statMap["DirectMap2M"],
+		DirectMap1G:       statMap["DirectMap1G"],
+	}
+
+	return
+}
+
+// parseKeyValue is parse `key: value [unit]` format file (ex: /proc/meminfo).
+func parseKeyValue(data string) (result map[string]uint64) {
+	result = map[string]uint64{}
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.SplitN(line, ":", 2)
+		if len(fields) < 2 {
+			continue
+		}
+
+		valFields := strings.Fields(fields[1])
+		if len(valFields) == 0 {
+			continue
+		}
+
+		val, _ := strconv.ParseUint(valFields[0], 10, 64)
+		result[strings.TrimSpace(fields[0])] = val
+	}
+
+	return
+}
+
+func readUptime(src ProcSource, path string) (uptime *linux.Uptime, err error) {
+	data, err := readString(src, path)
+	if err != nil {
+		return
+	}
+
+	fields := strings.Fields(data)
+	if len(fields) < 2 {
+		err = errors.New("Cannot parse uptime: " + data)
+		return
+	}
+
+	uptime = &linux.Uptime{}
+	if uptime.Total, err = strconv.ParseFloat(fields[0], 64); err != nil {
+		return nil, err
+	}
+	if uptime.Idle, err = strconv.ParseFloat(fields[1], 64); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func readLoadAvg(src ProcSource, path string) (loadavg *linux.LoadAvg, err error) {
+	data, err := readString(src, path)
+	if err != nil {
+		return
+	}
+
+	content := strings.TrimSpace(data)
+	fields := strings.Fields(content)
+	if len(fields) < 5 {
+		err = errors.New("Cannot parse loadavg: " + content)
+		return
+	}
+
+	process := strings.Split(fields[3], "/")
+	if len(process) != 2 {
+		err = errors.New("Cannot parse loadavg: " + content)
+		return
+	}
+
+	loadavg = &linux.LoadAvg{}
+	if loadavg.Last1Min, err = strconv.ParseFloat(fields[0], 64); err != nil {
+		return nil, err
+	}
+	if loadavg.Last5Min, err = strconv.ParseFloat(fields[1], 64); err != nil {
+		return nil, err
+	}
+	if loadavg.Last15Min, err = strconv.ParseFloat(fields[2], 64); err != nil {
+		return nil, err
+	}
+	if loadavg.ProcessRunning, err = strconv.ParseUint(process[0], 10, 64); err != nil {
+		return nil, err
+	}
+	if loadavg.ProcessTotal, err = strconv.ParseUint(process[1], 10, 64); err != nil {
+		return nil, err
+	}
+	if loadavg.LastPID, err = strconv.ParseUint(fields[4], 10, 64); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func readPressure(src ProcSource, path string) (pressure *Pressure, err error) {
+	data, err := readString(src, path)
+	if err != nil {
+		return
+	}
+
+	// some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+	// full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+	pressure = &Pressure{}
+	hasSome := false
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+
+		stat := PressureStat{}
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+
+			switch kv[0] {
+			case "avg10":
+				stat.Avg10, _ = strconv.ParseFloat(kv[1], 64)
+			case "avg60":
+				stat.Avg60, _ = strconv.ParseFloat(kv[1], 64)
+			case "avg300":
+				stat.Avg300, _ = strconv.ParseFloat(kv[1], 64)
+			case "total":
+				stat.Total, _ = strconv.ParseUint(kv[1], 10, 64)
+			}
+		}
+
+		switch fields[0] {
+		case "some":
+			pressure.Some = stat
+			hasSome = true
+		case "full":
+			pressure.Full = stat
+			pressure.HasFull = true
+		}
+	}
+
+	if !hasSome {
+		return nil, errors.New("Cannot parse pressure: " + path)
+	}
+
+	return
+}
+
+func readMounts(src ProcSource, path string) (mounts *linux.Mounts, err error) {
+	data, err := readString(src, path)
+	if err != nil {
+		return
+	}
+
+	mounts = &linux.Mounts{}
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+
+		mount := linux.Mount{
+			Device:     fields[0],
+			MountPoint: unescapeMountPath(fields[1]),
+			FSType:     fields[2],
+			Options:    fields[3],
+		}
+		mounts.Mounts = append(mounts.Mounts, mount)
+	}
+
+	return
+}
+
+// unescapeMountPath is unescape octal (ex: `\040`) in /proc/mounts.
+func unescapeMountPath(path string) string {
+	if !strings.Contains(path, `\`) {
+		return path
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			if v, err := strconv.ParseUint(path[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+
+	return b.String()
+}
+
+func readDiskStats(src ProcSource, path string) (stats []linux.DiskStat, err error) {
+	data, err := readString(src, path)
+	if err != nil {
+		return
+	}
+
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 14 {
+			continue
+		}
+
+		stat := linux.DiskStat{}
+		stat.Major, _ = strconv.Atoi(fields[0])
+		stat.Minor, _ = strconv.Atoi(fields[1])
+		stat.Name = fields[2]
+		stat.ReadIOs, _ = strconv.ParseUint(fields[3], 10, 64)
+		stat.ReadMerges, _ = strconv.ParseUint(fields[4], 10, 64)
+		stat.ReadSectors, _ = strconv.ParseUint(fields[5], 10, 64)
+		stat.ReadTicks, _ = strconv.ParseUint(fields[6], 10, 64)
+		stat.WriteIOs, _ = strconv.ParseUint(fields[7], 10, 64)
+		stat.WriteMerges, _ = strconv.ParseUint(fields[8], 10, 64)
+		stat.WriteSectors, _ = strconv.ParseUint(fields[9], 10, 64)
+		stat.WriteTicks, _ = strconv.ParseUint(fields[10], 10, 64)
+		stat.InFlight, _ = strconv.ParseUint(fields[11], 10, 64)
+		stat.IOTicks, _ = strconv.ParseUint(fields[12], 10, 64)
+		stat.TimeInQueue, _ = strconv.ParseUint(fields[13], 10, 64)
+
+		stats = append(stats, stat)
+	}
+
+	return
+}
+
+func readNetworkStat(src ProcSource, path string) (stats []linux.NetworkStat, err error) {
+	data, err := readString(src, path)
+	if err != nil {
+		return
+	}
+
+	for _, line := range strings.Split(data, "\n") {
+		// patterns
+		// <iface>: 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0
+		// or
+		// <iface>:0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 (without space after colon)
+		colon := strings.Index(line, ":")
+		if colon <= 0 {
+			continue
+		}
+
+		fields := strings.Fields(line[colon+1:])
+		if len(fields) < 16 {
+			continue
+		}
+
+		values := make([]uint64, 16)
+		for i := range values {
+			values[i], _ = strconv.ParseUint(fields[i], 10, 64)
+		}
+
+		stat := linux.NetworkStat{
+			Iface:        strings.TrimSpace(line[:colon]),
+			RxBytes:      values[0],
+			RxPackets:    values[1],
+			RxErrs:       values[2],
+			RxDrop:       values[3],
+			RxFifo:       values[4],
+			RxFrame:      values[5],
+			RxCompressed: values[6],
+			RxMulticast:  values[7],
+			TxBytes:      values[8],
+			TxPackets:    values[9],
+			TxErrs:       values[10],
+			TxDrop:       values[11],
+			TxFifo:       values[12],
+			TxColls:      values[13],
+			TxCarrier:    values[14],
+			TxCompressed: values[15],
+		}
+		stats = append(stats, stat)
+	}
+
+	return
+}
+
+func readFibTrie(src ProcSource, fibTriePath, routePath string) (result []IPv4, err error) {
+	ft, err := readString(src, fibTriePath)
+	if err != nil {
+		return
+	}
+
+	rt, err := readString(src, routePath)
+	if err != nil {
+		return
+	}
+
+	// Get local table only.
+	var local strings.Builder
+	in := false
+	for _, line := range strings.Split(ft, "\n") {
+		if strings.Contains(line, "Local:") {
+			in = true
+		}
+		if in {
+			local.WriteString(line + "\n")
+		}
+	}
+	ftLines := strings.Split(local.String(), "\n")
+
+	for _, rl := range strings.Split(rt, "\n") {
+		fields := strings.Fields(rl)
+		if len(fields) < 9 || fields[2] != "00000000" || fields[7] == "FFFFFFFF" {
+			continue
+		}
+
+		ifName := fields[0]
+		netDec := parseHexIPv4(fields[1])
+		maskDec := parseHexIPv4(fields[7])
+		if netDec == nil || maskDec == nil {
+			continue
+		}
+
+		var ipAddr string
+		inRange := false
+		for _, line := range ftLines {
+			if strings.Contains(line, netDec.String()) {
+				inRange = true
+			}
+			if inRange && strings.Contains(line, "32 host") {
+				inRange = false
+			}
+			if inRange {
+				fields := strings.Fields(line)
+				if len(fields) >= 2 {
+					ipAddr = fields[1]
+				}
+			}
+		}
+
+		ipSet := IPv4{
+			Interface: ifName,
+			IPAddress: net.ParseIP(ipAddr),
+			Netmask:   net.IPMask(maskDec.To4()),
+		}
+		result = append(result, ipSet)
+	}
+
+	return
+}
+
+// parseHexIPv4 is parse little endian hex address in /proc/net/route.
+func parseHexIPv4(s string) net.IP {
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil
+	}
+
+	return net.IPv4(byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func readIfInet6(src ProcSource, path string) (result []IPv6, err error) {
+	data, err := readString(src, path)
+	if err != nil {
+		return
+	}
+
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 6 || len(fields[0]) != 32 {
+			continue
+		}
+
+		ip := make(net.IP, net.IPv6len)
+		for i := 0; i < net.IPv6len; i++ {
+			v, _ := strconv.ParseUint(fields[0][i*2:i*2+2], 16, 8)
+			ip[i] = byte(v)
+		}
+
+		// prefix length is hex
+		prefix, _ := strconv.ParseUint(fields[2], 16, 8)
+
+		ipv6 := IPv6{
+			Interface: fields[5],
+			IPAddress: ip,
+			Prefix:    strconv.FormatUint(prefix, 10),
+		}
+		result = append(result, ipv6)
+	}
+
+	return
+}
+
+// listPID is return pid list in /proc.
+func listPID(src ProcSource, path string) (pids []uint64, err error) {
+	names, err := src.ReadDir(path)
+	if err != nil {
+		return
+	}
+
+	for _, name := range names {
+		if !pidRegExp.MatchString(name) {
+			continue
+		}
+
+		pid, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		pids = append(pids, pid)
+	}
+
+	return
+}
+
+func readProcessStat(src ProcSource, path string) (stat *linux.ProcessStat, err error) {
+	data, err := readString(src, path)
+	if err != nil {
+		return
+	}
+
+	e := processStatRegExp.FindStringSubmatch(strings.TrimSpace(data))
+	if e == nil {
+		err = fmt.Errorf("Cannot parse process stat: %s", path)
+		return
+	}
+
+	f := strings.Fields(e[3])
+	if len(f) < 22 {
+		err = fmt.Errorf("Cannot parse process stat: %s", path)
+		return
+	}
+
+	u := func(i int) uint64 {
+		v, _ := strconv.ParseUint(f[i], 10, 64)
+		return v
+	}
+	d := func(i int) int64 {
+		v, _ := strconv.ParseInt(f[i], 10, 64)
+		return v
+	}
+
+	stat = &linux.ProcessStat{}
+	stat.Pid, _ = strconv.ParseUint(e[1], 10, 64)
+	stat.Comm = strings.TrimSpace(e[2])
+	stat.State = f[0]
+	stat.Ppid = d(1)
+	stat.Pgrp = d(2)
+	stat.Session = d(3)
+	stat.TtyNr = d(4)
+	stat.Tpgid = d(5)
+	stat.Flags = u(6)
+	stat.Minflt = u(7)
+	stat.Cminflt = u(8)
+	stat.Majflt = u(9)
+	stat.Cmajflt = u(10)
+	stat.Utime = u(11)
+	stat.Stime = u(12)
+	stat.Cutime = d(13)
+	stat.Cstime = d(14)
+	stat.Priority = d(15)
+	stat.Nice = d(16)
+	stat.NumThreads = d(17)
+	stat.Itrealvalue = d(18)
+	stat.Starttime = u(19)
+	stat.Vsize = u(20)
+	stat.Rss = d(21)
+
+	return
+}
+
+func readProcessStatus(src ProcSource, path string) (status *linux.ProcessStatus, err error) {
+	data, err := readString(src, path)
+	if err != nil {
+		return
+	}
+
+	status = &linux.ProcessStatus{}
+	for _, line := range strings.Split(data, "\n") {
+		l := strings.SplitN(line, ":", 2)
+		if len(l) < 2 {
+			continue
+		}
+
+		k := strings.TrimSpace(l[0])
+		v := strings.TrimSpace(l[1])
+		f := strings.Fields(v)
+		if len(f) == 0 {
+			continue
+		}
+		n, _ := strconv.ParseUint(f[0], 10, 64)
+
+		switch k {
+		case "Name":
+			status.Name = v
+		case "State":
+			status.State = v
+		case "Tgid":
+			status.Tgid = n
+		case "Pid":
+			status.Pid = n
+		case "PPid":
+			status.PPid, _ = strconv.ParseInt(f[0], 10, 64)
+		case "Uid":
+			if len(f) == 4 {
+				status.RealUid = n
+				status.EffectiveUid, _ = strconv.ParseUint(f[1], 10, 64)
+				status.SavedSetUid, _ = strconv.ParseUint(f[2], 10, 64)
+				status.FilesystemUid, _ = strconv.ParseUint(f[3], 10, 64)
+			}
+		case "Gid":
+			if len(f) == 4 {
+				status.RealGid = n
+				status.EffectiveGid, _ = strconv.ParseUint(f[1], 10, 64)
+				status.SavedSetGid, _ = strconv.ParseUint(f[2], 10, 64)
+				status.FilesystemGid, _ = strconv.ParseUint(f[3], 10, 64)
+			}
+		case "FDSize":
+			status.FDSize = n
+		case "VmPeak":
+			status.VmPeak = n
+		case "VmSize":
+			status.VmSize = n
+		case "VmLck":
+			status.VmLck = n
+		case "VmHWM":
+			status.VmHWM = n
+		case "VmRSS":
+			status.VmRSS = n
+		case "VmData":
+			status.VmData = n
+		case "VmStk":
+			status.VmStk = n
+		case "VmExe":
+			status.VmExe = n
+		case "VmLib":
+			status.VmLib = n
+		case "VmPTE":
+			status.VmPTE = n
+		case "VmSwap":
+			status.VmSwap = n
+		case "Threads":
+			status.Threads = n
+		case "voluntary_ctxt_switches":
+			status.VoluntaryCtxtSwitches = n
+		case "nonvoluntary_ctxt_switches":
+			status.NonvoluntaryCtxtSwitches = n
+		}
+	}
+
+	return
+}
+
+func readProcessCmdline(src ProcSource, path string) (cmdline string, err error) {
+	b, err := src.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	// arguments are separated by '\0'
+	cmdline = strings.TrimSpace(strings.ReplaceAll(strings.TrimRight(string(b), "\x00"), "\x00", " "))
+	return
+}
@@ -0,0 +1,446 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"net"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/c9s/goprocinfo/linux"
+)
+
+// mapSource is ProcSource that returns the files and dirs in the map.
+type mapSource struct {
+	files map[string]string
+	dirs  map[string][]string
+}
+
+func (s *mapSource) Prefetch(req *PrefetchRequest) error { return nil }
+
+func (s *mapSource) ReadFile(path string) ([]byte, error) {
+	data, ok := s.files[path]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	}
+	return []byte(data), nil
+}
+
+func (s *mapSource) ReadDir(path string) ([]string, error) {
+	names, ok := s.dirs[path]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	}
+	return names, nil
+}
+
+func (s *mapSource) StatFS(path string) (*linux.Disk, error) {
+	return &linux.Disk{All: 4096 * 1000, Used: 4096 * 600, Free: 4096 * 400, FreeInodes: 50}, nil
+}
+
+func (s *mapSource) CheckAlive() bool { return true }
+
+func (s *mapSource) Close() error { return nil }
+
+func newFileSource(path, data string) *mapSource {
+	return &mapSource{files: map[string]string{path: data}}
+}
+
+func TestReadStat(t *testing.T) {
+	data := "cpu  100 1 50 1000 5 0 2 0 0 0\n" +
+		"cpu0 60 1 30 500 3 0 1 0 0 0\n" +
+		"cpu1 40 0 20 500 2 0 1 0 0 0\n" +
+		"intr 12345 0 0\n" +
+		"ctxt 6789\n" +
+		"btime 1700000000\n" +
+		"processes 100\n" +
+		"procs_running 2\n" +
+		"procs_blocked 1\n"
+
+	stat, err := readStat(newFileSource("/proc/stat", data), "/proc/stat")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := linux.CPUStat{Id: "cpu", User: 100, Nice: 1, System: 50, Idle: 1000, IOWait: 5, SoftIRQ: 2}
+	if stat.CPUStatAll != want {
+		t.Errorf("CPUStatAll = %+v, want %+v", stat.CPUStatAll, want)
+	}
+	if len(stat.CPUStats) != 2 || stat.CPUStats[1].Id != "cpu1" || stat.CPUStats[1].User != 40 {
+		t.Errorf("CPUStats = %+v", stat.CPUStats)
+	}
+	if stat.Interrupts != 12345 || stat.ContextSwitches != 6789 || stat.BootTime.Unix() != 1700000000 {
+		t.Errorf("stat = %+v", stat)
+	}
+	if stat.Processes != 100 || stat.ProcsRunning != 2 || stat.ProcsBlocked != 1 {
+		t.Errorf("stat = %+v", stat)
+	}
+}
+
+func TestReadCPUInfo(t *testing.T) {
+	data := "processor\t: 0\nvendor_id\t: GenuineIntel\nmodel name\t: Test CPU\ncpu MHz\t\t: 2400.000\nphysical id\t: 0\ncore id\t\t: 0\ncpu cores\t: 2\n\n" +
+		"processor\t: 1\nvendor_id\t: GenuineIntel\nmodel name\t: Test CPU\ncpu MHz\t\t: 2400.000\nphysical id\t: 0\ncore id\t\t: 1\ncpu cores\t: 2\n\n"
+
+	cpuinfo, err := readCPUInfo(newFileSource("/proc/cpuinfo", data), "/proc/cpuinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := cpuinfo.NumCPU(); got != 2 {
+		t.Errorf("NumCPU() = %d, want 2", got)
+	}
+
+	p := cpuinfo.Processors[1]
+	if p.Id != 1 || p.ModelName != "Test CPU" || p.MHz != 2400 || p.CoreId != 1 || p.Cores != 2 {
+		t.Errorf("Processors[1] = %+v", p)
+	}
+}
+
+func TestReadMemInfo(t *testing.T) {
+	data := "MemTotal:       16000000 kB\n" +
+		"MemFree:         4000000 kB\n" +
+		"MemAvailable:    8000000 kB\n" +
+		"Active(anon):     10000 kB\n" +
+		"SUnreclaim:        12345 kB\n" +
+		"HugePages_Total:       2\n"
+
+	meminfo, err := readMemInfo(newFileSource("/proc/meminfo", data), "/proc/meminfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &linux.MemInfo{
+		MemTotal:        16000000,
+		MemFree:         4000000,
+		MemAvailable:    8000000,
+		ActiveAnon:      10000,
+		SUnreclaim:      12345,
+		HugePages_Total: 2,
+	}
+	if !reflect.DeepEqual(meminfo, want) {
+		t.Errorf("readMemInfo() = %+v, want %+v", meminfo, want)
+	}
+}
+
+func TestReadUptime(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		uptime *linux.Uptime
+		err    bool
+	}{
+		{"normal", "3600.50 7000.25\n", &linux.Uptime{Total: 3600.5, Idle: 7000.25}, false},
+		{"short", "3600.50\n", nil, true},
+		{"invalid", "a b\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uptime, err := readUptime(newFileSource("/proc/uptime", tt.data), "/proc/uptime")
+			if (err != nil) != tt.err {
+				t.Fatalf("readUptime() error = %v, want error %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(uptime, tt.uptime) {
+				t.Errorf("readUptime() = %+v, want %+v", uptime, tt.uptime)
+			}
+		})
+	}
+}
+
+func TestReadLoadAvg(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		loadavg *linux.LoadAvg
+		err     bool
+	}{
+		{
+			"normal",
+			"0.10 0.20 0.30 2/150 12345\n",
+			&linux.LoadAvg{Last1Min: 0.1, Last5Min: 0.2, Last15Min: 0.3, ProcessRunning: 2, ProcessTotal: 150, LastPID: 12345},
+			false,
+		},
+		{"short", "0.10 0.20 0.30\n", nil, true},
+		{"invalid process", "0.10 0.20 0.30 2 12345\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadavg, err := readLoadAvg(newFileSource("/proc/loadavg", tt.data), "/proc/loadavg")
+			if (err != nil) != tt.err {
+				t.Fatalf("readLoadAvg() error = %v, want error %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(loadavg, tt.loadavg) {
+				t.Errorf("readLoadAvg() = %+v, want %+v", loadavg, tt.loadavg)
+			}
+		})
+	}
+}
+
+func TestReadPressure(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		pressure *Pressure
+		err      bool
+	}{
+		{
+			"some and full",
+			"some avg10=1.50 avg60=0.20 avg300=0.00 total=100\nfull avg10=0.50 avg60=0.10 avg300=0.00 total=50\n",
+			&Pressure{
+				Some:    PressureStat{Avg10: 1.5, Avg60: 0.2, Total: 100},
+				Full:    PressureStat{Avg10: 0.5, Avg60: 0.1, Total: 50},
+				HasFull: true,
+			},
+			false,
+		},
+		{
+			"cpu without full",
+			"some avg10=2.00 avg60=1.00 avg300=0.50 total=10\n",
+			&Pressure{Some: PressureStat{Avg10: 2, Avg60: 1, Avg300: 0.5, Total: 10}},
+			false,
+		},
+		{"invalid", "invalid\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pressure, err := readPressure(newFileSource("/proc/pressure/cpu", tt.data), "/proc/pressure/cpu")
+			if (err != nil) != tt.err {
+				t.Fatalf("readPressure() error = %v, want error %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(pressure, tt.pressure) {
+				t.Errorf("readPressure() = %+v, want %+v", pressure, tt.pressure)
+			}
+		})
+	}
+}
+
+func TestReadMounts(t *testing.T) {
+	data := "/dev/sda1 / ext4 rw,relatime 0 0\n" +
+		"/dev/sdb1 /mnt/my\\040disk xfs ro,relatime 0 0\n" +
+		"short line\n"
+
+	mounts, err := readMounts(newFileSource("/proc/mounts", data), "/proc/mounts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []linux.Mount{
+		{Device: "/dev/sda1", MountPoint: "/", FSType: "ext4", Options: "rw,relatime"},
+		{Device: "/dev/sdb1", MountPoint: "/mnt/my disk", FSType: "xfs", Options: "ro,relatime"},
+	}
+	if !reflect.DeepEqual(mounts.Mounts, want) {
+		t.Errorf("readMounts() = %+v, want %+v", mounts.Mounts, want)
+	}
+}
+
+func TestUnescapeMountPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/mnt/data", "/mnt/data"},
+		{`/mnt/my\040disk`, "/mnt/my disk"},
+		{`/mnt/tab\011dir`, "/mnt/tab\tdir"},
+		{`/mnt/back\134slash`, `/mnt/back\slash`},
+		{`/mnt/invalid\9`, `/mnt/invalid\9`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := unescapeMountPath(tt.path); got != tt.want {
+				t.Errorf("unescapeMountPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadDiskStats(t *testing.T) {
+	data := "   8       0 sda 100 1 2000 30 200 2 4000 60 0 90 120\n" +
+		"   8       1 sda1 short line\n"
+
+	stats, err := readDiskStats(newFileSource("/proc/diskstats", data), "/proc/diskstats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("len(stats) = %d, want 1", len(stats))
+	}
+
+	stat := stats[0]
+	if stat.Major != 8 || stat.Name != "sda" || stat.ReadSectors != 2000 || stat.WriteSectors != 4000 || stat.TimeInQueue != 120 {
+		t.Errorf("readDiskStats() = %+v", stat)
+	}
+}
+
+func TestReadNetworkStat(t *testing.T) {
+	data := "Inter-|   Receive                                                |  Transmit\n" +
+		" face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed\n" +
+		"    lo: 100 2 0 0 0 0 0 0 100 2 0 0 0 0 0 0\n" +
+		"  eth0:4294967296 10 1 2 0 0 0 0 200 20 0 0 0 0 0 0\n"
+
+	stats, err := readNetworkStat(newFileSource("/proc/net/dev", data), "/proc/net/dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		iface   string
+		rxBytes uint64
+		rxDrop  uint64
+		txBytes uint64
+	}{
+		{"lo", 100, 0, 100},
+		{"eth0", 4294967296, 2, 200},
+	}
+	if len(stats) != len(tests) {
+		t.Fatalf("len(stats) = %d, want %d", len(stats), len(tests))
+	}
+	for i, tt := range tests {
+		stat := stats[i]
+		if stat.Iface != tt.iface || stat.RxBytes != tt.rxBytes || stat.RxDrop != tt.rxDrop || stat.TxBytes != tt.txBytes {
+			t.Errorf("stats[%d] = %+v, want %+v", i, stat, tt)
+		}
+	}
+}
+
+func TestReadFibTrie(t *testing.T) {
+	src := &mapSource{files: map[string]string{
+		"/proc/net/route": "Iface\tDestination\tGateway \tFlags\tRefCnt\tUse\tMetric\tMask\t\tMTU\tWindow\tIRTT\n" +
+			"eth0\t00000000\t0100A8C0\t0003\t0\t0\t0\t00000000\t0\t0\t0\n" +
+			"eth0\t0000A8C0\t00000000\t0001\t0\t0\t0\t00FFFFFF\t0\t0\t0\n",
+		"/proc/net/fib_trie": "Main:\n" +
+			"  +-- 0.0.0.0/0 3 0 5\n" +
+			"Local:\n" +
+			"  +-- 192.168.0.0/24 2 0 2\n" +
+			"     |-- 192.168.0.10\n" +
+			"        /32 host LOCAL\n",
+	}}
+
+	got, err := readFibTrie(src, "/proc/net/fib_trie", "/proc/net/route")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []IPv4{
+		{"eth0", net.ParseIP("192.168.0.10"), net.CIDRMask(24, 32)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readFibTrie() = %v, want %v", got, want)
+	}
+}
+
+func TestReadIfInet6(t *testing.T) {
+	data := "00000000000000000000000000000001 01 80 10 80       lo\n" +
+		"fe80000000000000021122fffe334455 02 40 20 80     eth0\n" +
+		"invalid line\n"
+
+	got, err := readIfInet6(newFileSource("/proc/net/if_inet6", data), "/proc/net/if_inet6")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []IPv6{
+		{"lo", net.ParseIP("::1"), "128"},
+		{"eth0", net.ParseIP("fe80::211:22ff:fe33:4455"), "64"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readIfInet6() = %v, want %v", got, want)
+	}
+}
+
+func TestListPID(t *testing.T) {
+	src := &mapSource{dirs: map[string][]string{"/proc": {"1", "net", "123", "self", "4a"}}}
+
+	pids, err := listPID(src, "/proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pids, []uint64{1, 123}) {
+		t.Errorf("listPID() = %v, want [1 123]", pids)
+	}
+}
+
+func TestReadProcessStat(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		comm string
+		err  bool
+	}{
+		{"normal", "1 (init) S 0 1 1 0 -1 4194560 10 20 1 2 300 400 0 0 20 0 1 0 10 10000000 1000 18446744073709551615 0 0\n", "(init)", false},
+		{"space and paren in comm", "42 (my (proc) x) R 1 42 42 0 -1 4194560 10 20 1 2 300 400 0 0 20 0 1 0 10 10000000 1000\n", "(my (proc) x)", false},
+		{"short", "1 (init) S 0 1\n", "", true},
+		{"invalid", "invalid\n", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stat, err := readProcessStat(newFileSource("/proc/1/stat", tt.data), "/proc/1/stat")
+			if (err != nil) != tt.err {
+				t.Fatalf("readProcessStat() error = %v, want error %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+
+			if stat.Comm != tt.comm || stat.Utime != 300 || stat.Stime != 400 || stat.Rss != 1000 || stat.Starttime != 10 {
+				t.Errorf("readProcessStat() = %+v", stat)
+			}
+		})
+	}
+}
+
+func TestReadProcessStatus(t *testing.T) {
+	data := "Name:\tsshd\n" +
+		"State:\tS (sleeping)\n" +
+		"Pid:\t100\n" +
+		"PPid:\t1\n" +
+		"Uid:\t1000\t1001\t1002\t1003\n" +
+		"VmRSS:\t    4000 kB\n" +
+		"VmSwap:\t     100 kB\n" +
+		"Threads:\t3\n"
+
+	status, err := readProcessStatus(newFileSource("/proc/100/status", data), "/proc/100/status")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.Name != "sshd" || status.State != "S (sleeping)" || status.Pid != 100 || status.PPid != 1 {
+		t.Errorf("readProcessStatus() = %+v", status)
+	}
+	if status.RealUid != 1000 || status.EffectiveUid != 1001 || status.FilesystemUid != 1003 {
+		t.Errorf("Uid = %d %d %d", status.RealUid, status.EffectiveUid, status.FilesystemUid)
+	}
+	if status.VmRSS != 4000 || status.VmSwap != 100 || status.Threads != 3 {
+		t.Errorf("readProcessStatus() = %+v", status)
+	}
+}
+
+func TestReadProcessCmdline(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"args", "/usr/sbin/sshd\x00-D\x00", "/usr/sbin/sshd -D"},
+		{"kernel thread", "", ""},
+		{"no trailing nul", "sleep\x0010", "sleep 10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readProcessCmdline(newFileSource("/proc/1/cmdline", tt.data), "/proc/1/cmdline")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("readProcessCmdline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
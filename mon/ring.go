@@ -0,0 +1,122 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"sync"
+)
+
+// Ring is a fixed size ring buffer protected by lock. It is used to keep the history of metrics.
+// When the buffer is full, Push overwrites the oldest value.
+// Readers get only copies of the values, so they can be used after the buffer is changed.
+type Ring[T any] struct {
+	data  []T
+	start int
+	size  int
+
+	sync.RWMutex
+}
+
+// NewRing is create Ring with capacity.
+func NewRing[T any](capacity int) *Ring[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &Ring[T]{
+		data: make([]T, capacity),
+	}
+}
+
+// Push is add value as the latest.
+func (r *Ring[T]) Push(v T) {
+	r.Lock()
+	defer r.Unlock()
+
+	end := (r.start + r.size) % len(r.data)
+	r.data[end] = v
+
+	if r.size < len(r.data) {
+		r.size++
+	} else {
+		r.start = (r.start + 1) % len(r.data)
+	}
+}
+
+// Len is return the number of values.
+func (r *Ring[T]) Len() int {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.size
+}
+
+// Cap is return the capacity.
+func (r *Ring[T]) Cap() int {
+	r.RLock()
+	defer r.RUnlock()
+
+	return len(r.data)
+}
+
+// Latest is return the copy of the latest n values. The oldest is first.
+func (r *Ring[T]) Latest(n int) []T {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.latest(n)
+}
+
+// Values is return the copy of all values. The oldest is first.
+func (r *Ring[T]) Values() []T {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.latest(r.size)
+}
+
+func (r *Ring[T]) latest(n int) (values []T) {
+	if n > r.size {
+		n = r.size
+	}
+	if n < 0 {
+		n = 0
+	}
+
+	values = make([]T, n)
+	for i := 0; i < n; i++ {
+		values[i] = r.data[(r.start+r.size-n+i)%len(r.data)]
+	}
+
+	return
+}
+
+// Last is return the value pushed i times before the latest. Last(0) is the latest.
+func (r *Ring[T]) Last(i int) (v T, ok bool) {
+	r.RLock()
+	defer r.RUnlock()
+
+	if i < 0 || i >= r.size {
+		return
+	}
+
+	v = r.data[(r.start+r.size-1-i)%len(r.data)]
+	ok = true
+
+	return
+}
+
+// Reset is remove all values.
+func (r *Ring[T]) Reset() {
+	r.Lock()
+	defer r.Unlock()
+
+	var zero T
+	for i := range r.data {
+		r.data[i] = zero
+	}
+	r.start = 0
+	r.size = 0
+}
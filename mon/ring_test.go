@@ -0,0 +1,131 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRingWrap(t *testing.T) {
+	r := NewRing[int](3)
+	for i := 1; i <= 5; i++ {
+		r.Push(i)
+	}
+
+	if got := r.Len(); got != 3 {
+		t.Errorf("Len() = %d, want 3", got)
+	}
+	if got := r.Cap(); got != 3 {
+		t.Errorf("Cap() = %d, want 3", got)
+	}
+	if got := r.Values(); !reflect.DeepEqual(got, []int{3, 4, 5}) {
+		t.Errorf("Values() = %v, want [3 4 5]", got)
+	}
+	if got := r.Latest(2); !reflect.DeepEqual(got, []int{4, 5}) {
+		t.Errorf("Latest(2) = %v, want [4 5]", got)
+	}
+	if got := r.Latest(10); !reflect.DeepEqual(got, []int{3, 4, 5}) {
+		t.Errorf("Latest(10) = %v, want [3 4 5]", got)
+	}
+
+	tests := []struct {
+		i  int
+		v  int
+		ok bool
+	}{
+		{0, 5, true},
+		{2, 3, true},
+		{3, 0, false},
+		{-1, 0, false},
+	}
+	for _, tt := range tests {
+		if v, ok := r.Last(tt.i); v != tt.v || ok != tt.ok {
+			t.Errorf("Last(%d) = (%d, %v), want (%d, %v)", tt.i, v, ok, tt.v, tt.ok)
+		}
+	}
+}
+
+func TestRingLen(t *testing.T) {
+	r := NewRing[int](0)
+	if got := r.Cap(); got != 1 {
+		t.Errorf("Cap() of NewRing(0) = %d, want 1", got)
+	}
+
+	r = NewRing[int](4)
+	for i := 0; i < 4; i++ {
+		if got := r.Len(); got != i {
+			t.Errorf("Len() = %d, want %d", got, i)
+		}
+		r.Push(i)
+	}
+
+	r.Reset()
+	if got := r.Len(); got != 0 {
+		t.Errorf("Len() after Reset = %d, want 0", got)
+	}
+	if got := r.Values(); len(got) != 0 {
+		t.Errorf("Values() after Reset = %v, want empty", got)
+	}
+	if _, ok := r.Last(0); ok {
+		t.Errorf("Last(0) after Reset is ok")
+	}
+}
+
+func TestRingSnapshotCopy(t *testing.T) {
+	r := NewRing[int](3)
+	r.Push(1)
+	r.Push(2)
+
+	values := r.Values()
+	latest := r.Latest(2)
+
+	// the copies are not changed by Push and Reset
+	r.Push(3)
+	r.Push(4)
+	if !reflect.DeepEqual(values, []int{1, 2}) {
+		t.Errorf("Values() is changed by Push: %v", values)
+	}
+
+	r.Reset()
+	if !reflect.DeepEqual(latest, []int{1, 2}) {
+		t.Errorf("Latest() is changed by Reset: %v", latest)
+	}
+
+	// the ring is not changed by the copies
+	r.Push(5)
+	values = r.Values()
+	values[0] = 100
+	if v, _ := r.Last(0); v != 5 {
+		t.Errorf("Last(0) = %d, want 5", v)
+	}
+}
+
+func TestRingConcurrent(t *testing.T) {
+	r := NewRing[int](8)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			r.Push(i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			values := r.Values()
+			for j := 1; j < len(values); j++ {
+				if values[j] != values[j-1]+1 {
+					t.Errorf("Values() is not continuous: %v", values)
+					return
+				}
+			}
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"strings"
+	"time"
+
+	"github.com/c9s/goprocinfo/linux"
+)
+
+// Snapshot is the result of one sampling tick of Node.
+// It is not modified after published, so the views can read it without lock.
+// The views render only from Snapshot, and never read /proc directly.
+type Snapshot struct {
+	Timestamp time.Time
+	Connected bool
+
+	// System
+	KernelVersion string
+	Uptime        *linux.Uptime
+	LoadAvg       *linux.LoadAvg
+	Tasks         uint64
+
+	// CPU
+	CPUCore       int
+	CPUUsage      float64
+	CPUUsages     []float64 // cpu usage(%) history. The latest is first.
+	CPUCoreUsages []CPUUsageTop
+
+	// Memory
+	MemInfo *linux.MemInfo
+
+	// Disk
+	DiskUsages []*DiskUsage
+
+	// Network
+	NetworkUsages []*NetworkUsage
+
+	// Pressure. It is empty when PSI is not supported.
+	Pressures []*PressureUsage
+
+	// Process
+	Processes []*ProcessUsage
+
+	// CounterResets is the recent resets of counters. The oldest is first.
+	CounterResets []CounterReset
+}
+
+// SnapshotFunc is called when Node published new Snapshot.
+type SnapshotFunc func(s *Snapshot)
+
+// GetSnapshot is return the latest Snapshot. It never return nil.
+func (n *Node) GetSnapshot() *Snapshot {
+	n.RLock()
+	defer n.RUnlock()
+
+	if n.snapshot == nil {
+		return &Snapshot{}
+	}
+
+	return n.snapshot
+}
+
+// AddSnapshotFunc is add the function called each time Snapshot is published.
+func (n *Node) AddSnapshotFunc(f SnapshotFunc) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.snapshotFuncs = append(n.snapshotFuncs, f)
+}
+
+// collect is read all metrics once, and create Snapshot.
+func (n *Node) collect() (s *Snapshot) {
+	s = &Snapshot{
+		Timestamp: time.Now(),
+		Connected: n.CheckClientAlive(),
+	}
+
+	n.prefetch()
+
+	// check reboot before update histories
+	if s.Connected {
+		s.Uptime, _ = n.GetUptime()
+		n.checkUptime(s.Uptime)
+	}
+
+	// update histories
+	n.MonitoringCPUUsage()
+	n.MonitoringDiskIO()
+	n.MonitoringNetworkIO()
+	n.MonitoringPressure()
+	n.MonitoringProcess()
+
+	if !s.Connected {
+		return
+	}
+
+	// System
+	s.KernelVersion, _ = n.GetKernelVersion()
+	s.LoadAvg, _ = n.GetLoadAvg()
+
+	// CPU
+	s.CPUCore, _ = n.GetCPUCore()
+	s.CPUUsages = n.GetCPUUsages(n.getGraphCount(21))
+	if len(s.CPUUsages) > 0 {
+		s.CPUUsage = s.CPUUsages[0]
+	}
+	s.CPUCoreUsages, _ = n.GetCPUCoreUsage()
+
+	// Memory
+	s.MemInfo, _ = n.GetMemInfo()
+
+	// Disk
+	s.DiskUsages, _ = n.GetDiskUsage()
+
+	// Network
+	s.NetworkUsages, _ = n.GetNetworkUsage()
+
+	// Pressure
+	s.Pressures, _ = n.GetPressureUsage()
+
+	// Process
+	s.Processes, _ = n.GetProcessUsage()
+	s.Tasks = uint64(len(s.Processes))
+
+	s.CounterResets = n.counterResets.Values()
+
+	return
+}
+
+// publish is set Snapshot as the latest, and call SnapshotFuncs.
+func (n *Node) publish(s *Snapshot) {
+	n.Lock()
+	n.snapshot = s
+	funcs := make([]SnapshotFunc, len(n.snapshotFuncs))
+	copy(funcs, n.snapshotFuncs)
+	n.Unlock()
+
+	for _, f := range funcs {
+		f(s)
+	}
+}
+
+// GetMemoryUsage is get memory usage. return size is byte.
+func (s *Snapshot) GetMemoryUsage() (memUsed, memTotal, swapUsed, swapTotal uint64) {
+	meminfo := s.MemInfo
+	if meminfo == nil {
+		return
+	}
+
+	// memory
+	memUsed = getMemUsed(meminfo) * 1024
+	memTotal = (meminfo.MemTotal) * 1024
+
+	// swap
+	swapUsed = getSwapUsed(meminfo) * 1024
+	swapTotal = (meminfo.SwapTotal) * 1024
+
+	return
+}
+
+// getMemUsed is return used memory(kB) calculated from MemAvailable.
+// MemAvailable is not exist before Linux 3.14, so it is estimated from free, page cache and reclaimable slab.
+func getMemUsed(meminfo *linux.MemInfo) uint64 {
+	available := meminfo.MemAvailable
+	if available == 0 {
+		available = meminfo.MemFree + meminfo.Buffers + meminfo.Cached + meminfo.SReclaimable
+		if available > meminfo.Shmem {
+			available -= meminfo.Shmem
+		}
+	}
+
+	// MemAvailable can exceed MemTotal in container (ex: lxcfs).
+	if available > meminfo.MemTotal {
+		return 0
+	}
+
+	return meminfo.MemTotal - available
+}
+
+// getSwapUsed is return used swap(kB).
+func getSwapUsed(meminfo *linux.MemInfo) uint64 {
+	if meminfo.SwapFree > meminfo.SwapTotal {
+		return 0
+	}
+
+	return meminfo.SwapTotal - meminfo.SwapFree
+}
+
+// GetCPUSparkline is return sparkline of cpu usage history.
+func (s *Snapshot) GetCPUSparkline(count int) (sparkline string) {
+	usages := s.CPUUsages
+	if len(usages) > count {
+		usages = usages[:count]
+	}
+
+	if len(usages) > 2 {
+		graph := Graph{
+			Data: usages,
+			Max:  100,
+			Min:  0,
+		}
+
+		sparkline = strings.Join(graph.Sparkline(), "")
+	}
+
+	return
+}
+
+// GetCPUBrailleLine is return braille line graph of cpu usage history.
+func (s *Snapshot) GetCPUBrailleLine(count int) (brailleLine string) {
+	usages := s.CPUUsages
+	if len(usages) > count {
+		usages = usages[:count]
+	}
+
+	if len(usages) > 0 {
+		graph := Graph{
+			Data: usages,
+			Max:  100,
+			Min:  0,
+		}
+
+		brailleLine = strings.Join(graph.BrailleLine(), "")
+	}
+
+	return
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestSource is return mapSource that has /proc files of a small machine.
+// The counters are increased by tick, so that the rates are not 0.
+func newTestSource(tick int) *mapSource {
+	return &mapSource{
+		files: map[string]string{
+			"/proc/stat": fmt.Sprintf("cpu  %d 0 %d %d 0 0 0 0 0 0\n", 100+tick*10, 50+tick*5, 1000+tick*20) +
+				fmt.Sprintf("cpu0 %d 0 %d %d 0 0 0 0 0 0\n", 100+tick*10, 50+tick*5, 1000+tick*20) +
+				fmt.Sprintf("ctxt %d\nbtime 1700000000\nprocesses 100\nprocs_running 1\nprocs_blocked 0\n", 1000+tick*100),
+			"/proc/meminfo": "MemTotal:       16000000 kB\nMemFree:         4000000 kB\nMemAvailable:    8000000 kB\n" +
+				"Buffers:          100000 kB\nCached:          2000000 kB\nSwapTotal:       2000000 kB\nSwapFree:        1000000 kB\n",
+			"/proc/vmstat":    fmt.Sprintf("pgpgin %d\npgpgout %d\npswpin 0\npswpout 0\n", tick*10, tick*20),
+			"/proc/uptime":    fmt.Sprintf("%d.00 7000.00\n", 3600+tick),
+			"/proc/loadavg":   "0.10 0.20 0.30 1/100 1\n",
+			"/proc/version":   "Linux version 6.1.0 (test)\n",
+			"/proc/cpuinfo":   "processor\t: 0\nmodel name\t: test\ncpu cores\t: 1\n\n",
+			"/proc/mounts":    "/dev/sda1 / ext4 rw,relatime 0 0\n",
+			"/proc/diskstats": fmt.Sprintf("   8       1 sda1 %d 0 %d 0 %d 0 %d 0 0 0 0\n", tick, tick*8, tick, tick*16),
+			"/proc/net/dev": "Inter-| Receive | Transmit\n face |bytes packets|bytes packets\n" +
+				fmt.Sprintf("  eth0: %d %d 0 0 0 0 0 0 %d %d 0 0 0 0 0 0\n", tick*1000, tick, tick*2000, tick*2),
+			"/proc/net/route":    "Iface\tDestination\tGateway\tFlags\tRefCnt\tUse\tMetric\tMask\tMTU\n eth0\t0000A8C0\t00000000\t0001\t0\t0\t0\t00FFFFFF\t0\n",
+			"/proc/net/fib_trie": "Local:\n     |-- 192.168.0.10\n        /32 host LOCAL\n",
+			"/proc/net/if_inet6": "fe80000000000000021122fffe334455 02 40 20 80     eth0\n",
+			"/proc/net/sockstat": "TCP: inuse 4 orphan 0 tw 2 alloc 5 mem 1\n",
+			"/proc/net/snmp":     fmt.Sprintf("Tcp: ActiveOpens OutSegs RetransSegs\nTcp: 1 %d %d\n", 1000+tick*100, tick),
+			"/proc/net/tcp":      "  sl  local_address rem_address   st\n   0: 00000000:0016 00000000:0000 0A\n",
+			"/proc/1/stat":       fmt.Sprintf("1 (init) S 0 1 1 0 -1 4194560 0 0 0 0 %d %d 0 0 20 0 1 0 10 10000000 1000 18446744073709551615 0 0 0 0 0 0 0 0 0 0 0 0 17 0 0 0 0 0 0\n", tick, tick),
+			"/proc/1/status":     "Name:\tinit\nState:\tS (sleeping)\nPPid:\t0\nUid:\t0\t0\t0\t0\nGid:\t0\t0\t0\t0\nVmRSS:\t    4000 kB\nThreads:\t1\n",
+			"/proc/1/cmdline":    "/sbin/init\x00",
+			"/proc/1/io":         fmt.Sprintf("rchar: 0\nwchar: 0\nsyscr: 0\nsyscw: 0\nread_bytes: %d\nwrite_bytes: %d\ncancelled_write_bytes: 0\n", tick*4096, tick*8192),
+			"/etc/passwd":        "root:x:0:0:root:/root:/bin/sh\n",
+		},
+		dirs: map[string][]string{
+			"/proc":               {"1", "net", "stat"},
+			"/sys/class/net":      {"eth0"},
+			"/sys/class/net/eth0": {"operstate", "mtu"},
+		},
+	}
+}
+
+// renderTop is update the panels of NodeTop with snapshot, as the view does.
+// The panels are recreated by the node at disconnected Snapshot, so it is not rendered.
+func renderTop(top *NodeTop, snapshot *Snapshot) {
+	if !snapshot.Connected {
+		return
+	}
+
+	top.Lock()
+	defer top.Unlock()
+
+	wg := sync.WaitGroup{}
+	wg.Add(6)
+	top.CPUUsage.Update(snapshot, &wg)
+	top.MemoryUsage.Update(snapshot, &wg)
+	top.Uptimes.Update(snapshot, &wg)
+	top.DiskUsage.Update(snapshot, &wg)
+	top.NetworkUsage.Update(snapshot, &wg)
+	top.Process.Update(snapshot, &wg)
+	wg.Wait()
+}
+
+// TestSnapshotConcurrent is run the sampler and the renderer at the same time. It is meaningful with -race.
+func TestSnapshotConcurrent(t *testing.T) {
+	n := NewNode("test", time.Millisecond, 10)
+	n.setSource(newTestSource(0))
+
+	m := &Monitor{Option: &Option{}, Nodes: []*Node{n}}
+
+	const ticks = 20
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// sampler
+	go func() {
+		defer wg.Done()
+		defer close(done)
+
+		for i := 1; i <= ticks; i++ {
+			n.setSource(newTestSource(i))
+			n.publish(n.collect())
+		}
+	}()
+
+	// renderer
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			snapshot := n.GetSnapshot()
+			renderTop(n.NodeTop, snapshot)
+			m.updateBaseGridTableAtNode(snapshot)
+		}
+	}()
+
+	wg.Wait()
+
+	snapshot := n.GetSnapshot()
+	if !snapshot.Connected {
+		t.Fatal("snapshot is not connected")
+	}
+	if snapshot.CPUCore != 1 {
+		t.Errorf("CPUCore = %d, want 1", snapshot.CPUCore)
+	}
+	if snapshot.MemInfo == nil || snapshot.MemInfo.MemTotal != 16000000 {
+		t.Errorf("MemInfo = %+v", snapshot.MemInfo)
+	}
+	if len(snapshot.NetworkUsages) == 0 {
+		t.Errorf("NetworkUsages is empty")
+	}
+	if len(snapshot.Processes) != 1 {
+		t.Errorf("len(Processes) = %d, want 1", len(snapshot.Processes))
+	}
+}
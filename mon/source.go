@@ -0,0 +1,70 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"fmt"
+
+	"github.com/blacknon/go-sshlib"
+	"github.com/c9s/goprocinfo/linux"
+)
+
+const (
+	// BackendSFTP reads each /proc file over SFTP.
+	BackendSFTP = "sftp"
+
+	// BackendExec runs one remote shell command per tick and receives all files in one framed stream.
+	BackendExec = "exec"
+
+	// BackendLocal reads /proc and /sys from the local filesystem.
+	BackendLocal = "local"
+)
+
+// ProcSource is the transport used by Node to read /proc and /sys.
+// The parse of the file contents is done by Node, so the implementation only needs to return raw data.
+type ProcSource interface {
+	// Prefetch is called at the beginning of each tick with the paths to be read in that tick.
+	// Implementations that can read multiple files at once fetch and cache them here.
+	Prefetch(req *PrefetchRequest) error
+
+	// ReadFile is read the file contents.
+	ReadFile(path string) ([]byte, error)
+
+	// ReadDir is return the entry names in the directory.
+	ReadDir(path string) ([]string, error)
+
+	// StatFS is return the filesystem usage of the path.
+	StatFS(path string) (*linux.Disk, error)
+
+	// CheckAlive is return whether the source can be read.
+	CheckAlive() bool
+
+	// Close is close the source.
+	Close() error
+}
+
+// PrefetchRequest is the list of paths read in one tick.
+// Files may contain glob pattern (ex: /proc/[0-9]*/stat).
+type PrefetchRequest struct {
+	Files  []string
+	Dirs   []string
+	StatFS []string
+}
+
+// NewProcSource is create ProcSource for backend.
+func NewProcSource(backend string, con *sshlib.Connect) (src ProcSource, err error) {
+	switch backend {
+	case BackendSFTP, "":
+		src, err = NewSFTPSource(con)
+	case BackendExec:
+		src, err = NewExecSource(con)
+	case BackendLocal:
+		src = NewLocalSource()
+	default:
+		err = fmt.Errorf("Unknown backend: %s", backend)
+	}
+
+	return
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/blacknon/go-sshlib"
+	"github.com/c9s/goprocinfo/linux"
+)
+
+// ExecSource is ProcSource that runs one remote shell command per tick.
+// All files requested by Prefetch are returned in one framed stream, and cached until the next Prefetch.
+//
+// Stream format:
+//
+//	<boundary> <kind> <path>\n
+//	<data>
+//	\n<boundary> E <exit status>\n
+//
+// kind is F(file), D(directory) or S(statfs).
+type ExecSource struct {
+	con      *sshlib.Connect
+	boundary string
+
+	files    map[string]*execEntry
+	dirs     map[string]*execEntry
+	statfs   map[string]*execEntry
+	patterns []string
+
+	closed bool
+
+	sync.RWMutex
+}
+
+type execEntry struct {
+	data []byte
+	err  error
+}
+
+// NewExecSource is create ExecSource.
+func NewExecSource(con *sshlib.Connect) (src *ExecSource, err error) {
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+
+	src = &ExecSource{
+		con:      con,
+		boundary: "LSMON-" + hex.EncodeToString(b),
+		files:    map[string]*execEntry{},
+		dirs:     map[string]*execEntry{},
+		statfs:   map[string]*execEntry{},
+	}
+
+	return
+}
+
+func (s *ExecSource) Prefetch(req *PrefetchRequest) (err error) {
+	script := s.createScript(req)
+
+	out, err := s.run(script)
+	if err != nil {
+		return
+	}
+
+	files, dirs, statfs := s.parseStream(out)
+
+	patterns := []string{}
+	for _, f := range req.Files {
+		if isGlobPath(f) {
+			patterns = append(patterns, f)
+		}
+	}
+
+	s.Lock()
+	s.files = files
+	s.dirs = dirs
+	s.statfs = statfs
+	s.patterns = patterns
+	s.Unlock()
+
+	return
+}
+
+func (s *ExecSource) ReadFile(path string) (data []byte, err error) {
+	s.RLock()
+	entry, ok := s.files[path]
+	matched := s.matchPattern(path)
+	s.RUnlock()
+
+	if ok {
+		return entry.data, entry.err
+	}
+
+	// the file matched to glob was not exist at Prefetch (ex: process is exited).
+	if matched {
+		err = &os.PathError{Op: "read", Path: path, Err: os.ErrNotExist}
+		return
+	}
+
+	return s.run(fmt.Sprintf("cat %s", shellQuote(path)))
+}
+
+func (s *ExecSource) ReadDir(path string) (names []string, err error) {
+	s.RLock()
+	entry, ok := s.dirs[path]
+	s.RUnlock()
+
+	var data []byte
+	if ok {
+		data, err = entry.data, entry.err
+	} else {
+		data, err = s.run(fmt.Sprintf("ls -1a %s", shellQuote(path)))
+	}
+	if err != nil {
+		return
+	}
+
+	for _, name := range strings.Split(string(data), "\n") {
+		if name == "" || name == "." || name == ".." {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	return
+}
+
+func (s *ExecSource) StatFS(path string) (disk *linux.Disk, err error) {
+	s.RLock()
+	entry, ok := s.statfs[path]
+	s.RUnlock()
+
+	var data []byte
+	if ok {
+		data, err = entry.data, entry.err
+	} else {
+		data, err = s.run(fmt.Sprintf("stat -f -c '%%S %%b %%f %%a %%c %%d' %s", shellQuote(path)))
+	}
+	if err != nil {
+		return
+	}
+
+	// %S: block size, %b: total blocks, %f: free blocks, %a: available blocks, %c: total inodes, %d: free inodes
+	fields := strings.Fields(string(data))
+	if len(fields) < 6 {
+		err = fmt.Errorf("Cannot parse statfs: %s", path)
+		return
+	}
+
+	values := make([]uint64, 6)
+	for i := range values {
+		if values[i], err = strconv.ParseUint(fields[i], 10, 64); err != nil {
+			return
+		}
+	}
+
+	disk = &linux.Disk{}
+	disk.All = values[1] * values[0]
+	disk.Free = values[2] * values[0]
+	disk.Used = disk.All - disk.Free
+	disk.FreeInodes = values[5]
+
+	return
+}
+
+func (s *ExecSource) CheckAlive() bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return !s.closed && s.con != nil && s.con.Client != nil
+}
+
+func (s *ExecSource) Close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.closed = true
+	return nil
+}
+
+// run is exec command on remote shell, and return stdout.
+func (s *ExecSource) run(script string) (out []byte, err error) {
+	session, err := s.con.CreateSession()
+	if err != nil {
+		// session can not be created, so connection is lost.
+		s.Close()
+		return
+	}
+	defer session.Close()
+
+	// Use `sh -s` so that the login shell of the user does not matter.
+	session.Stdin = strings.NewReader(script)
+	out, err = session.Output("sh -s")
+
+	return
+}
+
+// createScript is create shell script to read all requested paths.
+func (s *ExecSource) createScript(req *PrefetchRequest) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "B=%s\n", s.boundary)
+
+	if len(req.Dirs) > 0 {
+		fmt.Fprintf(&b, "for f in %s; do ", shellArgs(req.Dirs))
+		b.WriteString(`printf '%s D %s\n' "$B" "$f"; ls -1a "$f" 2>/dev/null; printf '\n%s E %d\n' "$B" $?; done` + "\n")
+	}
+
+	if len(req.Files) > 0 {
+		fmt.Fprintf(&b, "for f in %s; do ", shellArgs(req.Files))
+		b.WriteString(`printf '%s F %s\n' "$B" "$f"; cat "$f" 2>/dev/null; printf '\n%s E %d\n' "$B" $?; done` + "\n")
+	}
+
+	if len(req.StatFS) > 0 {
+		fmt.Fprintf(&b, "for f in %s; do ", shellArgs(req.StatFS))
+		b.WriteString(`printf '%s S %s\n' "$B" "$f"; stat -f -c '%S %b %f %a %c %d' "$f" 2>/dev/null; printf '\n%s E %d\n' "$B" $?; done` + "\n")
+	}
+
+	return b.String()
+}
+
+// parseStream is parse framed stream output by createScript.
+func (s *ExecSource) parseStream(out []byte) (files, dirs, statfs map[string]*execEntry) {
+	files = map[string]*execEntry{}
+	dirs = map[string]*execEntry{}
+	statfs = map[string]*execEntry{}
+
+	header := []byte(s.boundary + " ")
+	footer := []byte("\n" + s.boundary + " E ")
+
+	for bytes.HasPrefix(out, header) {
+		// header line
+		nl := bytes.IndexByte(out, '\n')
+		if nl < 0 {
+			break
+		}
+		line := string(out[len(header):nl])
+		out = out[nl+1:]
+
+		if len(line) < 3 {
+			break
+		}
+		kind := line[0]
+		path := line[2:]
+
+		// data
+		end := bytes.Index(out, footer)
+		if end < 0 {
+			break
+		}
+		data := out[:end]
+		out = out[end+len(footer):]
+
+		// exit status
+		nl = bytes.IndexByte(out, '\n')
+		if nl < 0 {
+			break
+		}
+		status := string(out[:nl])
+		out = out[nl+1:]
+
+		entry := &execEntry{data: data}
+		if status != "0" {
+			entry.data = nil
+			entry.err = &os.PathError{Op: "read", Path: path, Err: os.ErrNotExist}
+		}
+
+		switch kind {
+		case 'F':
+			files[path] = entry
+		case 'D':
+			dirs[path] = entry
+		case 'S':
+			statfs[path] = entry
+		}
+	}
+
+	return
+}
+
+func (s *ExecSource) matchPattern(path string) bool {
+	for _, pattern := range s.patterns {
+		if ok, _ := filepath.Match(pattern, path); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isGlobPath(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// shellArgs is join paths for shell arguments. glob pattern is not quoted to expand it on remote.
+func shellArgs(paths []string) string {
+	args := []string{}
+	for _, p := range paths {
+		if isGlobPath(p) && !strings.ContainsAny(p, " '\"$`\\;&|<>(){}") {
+			args = append(args, p)
+		} else {
+			args = append(args, shellQuote(p))
+		}
+	}
+
+	return strings.Join(args, " ")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// prefetchLocal is run the script of Prefetch by local sh instead of ssh, and set the result to s.
+func prefetchLocal(t *testing.T, s *ExecSource, req *PrefetchRequest) {
+	t.Helper()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not found")
+	}
+
+	cmd := exec.Command("sh", "-s")
+	cmd.Stdin = strings.NewReader(s.createScript(req))
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("script error: %v", err)
+	}
+
+	s.files, s.dirs, s.statfs = s.parseStream(out)
+	s.patterns = nil
+	for _, f := range req.Files {
+		if isGlobPath(f) {
+			s.patterns = append(s.patterns, f)
+		}
+	}
+}
+
+func TestExecSourceParseStream(t *testing.T) {
+	s, err := NewExecSource(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := s.boundary
+
+	out := b + " F /proc/uptime\n" +
+		"100.00 200.00\n" +
+		"\n" + b + " E 0\n" +
+		// the boundary in data without `E` is not the end of frame
+		b + " F /proc/1/cmdline\n" +
+		"echo " + b + " F /etc/passwd\n" +
+		"\n" + b + " E 0\n" +
+		b + " F /proc/missing\n" +
+		"\n" + b + " E 1\n" +
+		b + " D /proc\n" +
+		".\n..\n1\n" +
+		"\n" + b + " E 0\n" +
+		b + " S /\n" +
+		"4096 1000 400 300 100 50\n" +
+		"\n" + b + " E 0\n" +
+		// truncated frame is ignored
+		b + " F /proc/stat\n" +
+		"cpu 1 2 3"
+
+	files, dirs, statfs := s.parseStream([]byte(out))
+
+	tests := []struct {
+		name    string
+		entries map[string]*execEntry
+		path    string
+		data    string
+		exist   bool
+	}{
+		{"file", files, "/proc/uptime", "100.00 200.00\n", true},
+		{"boundary in data", files, "/proc/1/cmdline", "echo " + b + " F /etc/passwd\n", true},
+		{"missing file", files, "/proc/missing", "", false},
+		{"dir", dirs, "/proc", ".\n..\n1\n", true},
+		{"statfs", statfs, "/", "4096 1000 400 300 100 50\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, ok := tt.entries[tt.path]
+			if !ok {
+				t.Fatalf("%s is not parsed", tt.path)
+			}
+			if string(entry.data) != tt.data {
+				t.Errorf("data = %q, want %q", entry.data, tt.data)
+			}
+			if tt.exist != (entry.err == nil) {
+				t.Errorf("err = %v", entry.err)
+			}
+			if !tt.exist && !os.IsNotExist(entry.err) {
+				t.Errorf("err = %v, want not exist", entry.err)
+			}
+		})
+	}
+
+	if _, ok := files["/proc/stat"]; ok {
+		t.Errorf("truncated frame is parsed")
+	}
+	if _, ok := files["/etc/passwd"]; ok {
+		t.Errorf("boundary in data is parsed as frame")
+	}
+}
+
+func TestExecSourceScript(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range map[string]string{"a.txt": "a\n", "b.txt": "", "c.log": "c"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err := NewExecSource(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prefetchLocal(t, s, &PrefetchRequest{
+		Files: []string{
+			filepath.Join(dir, "c.log"),
+			filepath.Join(dir, "missing"),
+			filepath.Join(dir, "*.txt"),
+			filepath.Join(dir, "*.none"),
+		},
+		Dirs:   []string{dir},
+		StatFS: []string{dir},
+	})
+
+	tests := []struct {
+		name  string
+		path  string
+		data  string
+		exist bool
+	}{
+		{"file", filepath.Join(dir, "c.log"), "c", true},
+		{"missing file", filepath.Join(dir, "missing"), "", false},
+		{"glob", filepath.Join(dir, "a.txt"), "a\n", true},
+		{"glob empty file", filepath.Join(dir, "b.txt"), "", true},
+		{"glob not matched", filepath.Join(dir, "x.none"), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := s.ReadFile(tt.path)
+			if tt.exist && err != nil {
+				t.Fatalf("ReadFile(%q) error: %v", tt.path, err)
+			}
+			if !tt.exist && !os.IsNotExist(err) {
+				t.Fatalf("ReadFile(%q) error = %v, want not exist", tt.path, err)
+			}
+			if string(data) != tt.data {
+				t.Errorf("ReadFile(%q) = %q, want %q", tt.path, data, tt.data)
+			}
+		})
+	}
+
+	names, err := s.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names, ",") != "a.txt,b.txt,c.log" {
+		t.Errorf("ReadDir() = %v", names)
+	}
+
+	disk, err := s.StatFS(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if disk.All == 0 {
+		t.Errorf("StatFS() = %+v", disk)
+	}
+}
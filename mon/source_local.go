@@ -0,0 +1,53 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"os"
+
+	"github.com/c9s/goprocinfo/linux"
+)
+
+// LocalSource is ProcSource that reads the local filesystem.
+type LocalSource struct{}
+
+// NewLocalSource is create LocalSource.
+func NewLocalSource() *LocalSource {
+	return &LocalSource{}
+}
+
+// Prefetch is nothing to do, local read is cheap enough.
+func (s *LocalSource) Prefetch(req *PrefetchRequest) error {
+	return nil
+}
+
+func (s *LocalSource) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func (s *LocalSource) ReadDir(path string) (names []string, err error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return
+	}
+
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+
+	return
+}
+
+func (s *LocalSource) StatFS(path string) (*linux.Disk, error) {
+	return localStatFS(path)
+}
+
+func (s *LocalSource) CheckAlive() bool {
+	return true
+}
+
+func (s *LocalSource) Close() error {
+	return nil
+}
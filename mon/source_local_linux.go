@@ -0,0 +1,29 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+//go:build linux
+
+package monitor
+
+import (
+	"syscall"
+
+	"github.com/c9s/goprocinfo/linux"
+)
+
+func localStatFS(path string) (disk *linux.Disk, err error) {
+	fs := syscall.Statfs_t{}
+	err = syscall.Statfs(path, &fs)
+	if err != nil {
+		return
+	}
+
+	disk = &linux.Disk{}
+	disk.All = fs.Blocks * uint64(fs.Bsize)
+	disk.Free = fs.Bfree * uint64(fs.Bsize)
+	disk.Used = disk.All - disk.Free
+	disk.FreeInodes = fs.Ffree
+
+	return
+}
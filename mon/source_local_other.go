@@ -0,0 +1,19 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+//go:build !linux
+
+package monitor
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/c9s/goprocinfo/linux"
+)
+
+func localStatFS(path string) (disk *linux.Disk, err error) {
+	err = fmt.Errorf("statfs is not supported on %s", runtime.GOOS)
+	return
+}
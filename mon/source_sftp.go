@@ -0,0 +1,91 @@
+// Copyright (c) 2024 Blacknon. All rights reserved.
+// Use of this source code is governed by an MIT license
+// that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"io"
+
+	"github.com/blacknon/go-sshlib"
+	"github.com/c9s/goprocinfo/linux"
+	"github.com/pkg/sftp"
+)
+
+// SFTPSource is ProcSource that reads each file over SFTP.
+type SFTPSource struct {
+	con  *sshlib.Connect
+	sftp *sftp.Client
+}
+
+// NewSFTPSource is create SFTPSource with new sftp client.
+func NewSFTPSource(con *sshlib.Connect) (src *SFTPSource, err error) {
+	client, err := sftp.NewClient(con.Client)
+	if err != nil {
+		return
+	}
+
+	src = &SFTPSource{
+		con:  con,
+		sftp: client,
+	}
+
+	return
+}
+
+// Prefetch is nothing to do, SFTPSource reads each file when requested.
+func (s *SFTPSource) Prefetch(req *PrefetchRequest) error {
+	return nil
+}
+
+func (s *SFTPSource) ReadFile(path string) (data []byte, err error) {
+	file, err := s.sftp.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	data, err = io.ReadAll(file)
+	return
+}
+
+func (s *SFTPSource) ReadDir(path string) (names []string, err error) {
+	fs, err := s.sftp.ReadDir(path)
+	if err != nil {
+		return
+	}
+
+	for _, f := range fs {
+		names = append(names, f.Name())
+	}
+
+	return
+}
+
+func (s *SFTPSource) StatFS(path string) (disk *linux.Disk, err error) {
+	fs, err := s.sftp.StatVFS(path)
+	if err != nil {
+		return
+	}
+
+	disk = &linux.Disk{}
+	disk.All = fs.Blocks * fs.Bsize
+	disk.Free = fs.Bfree * fs.Bsize
+	disk.Used = disk.All - disk.Free
+	disk.FreeInodes = fs.Ffree
+
+	return
+}
+
+func (s *SFTPSource) CheckAlive() bool {
+	if s.sftp == nil {
+		return false
+	}
+
+	_, err := s.sftp.ReadDir(".")
+	return err == nil
+}
+
+func (s *SFTPSource) Close() error {
+	return s.sftp.Close()
+}